@@ -6,54 +6,160 @@ import (
 	"os"
 	"strings"
 
+	"github.com/lyeslabs/mcpgen/internal/converter"
 	"github.com/lyeslabs/mcpgen/internal/generator"
 )
 
 func main() {
 
 	// Define command-line flags
-	inputFile := flag.String("input", "", "Path to the OpenAPI specification file (JSON or YAML)")
+	configFile := flag.String("config", "", "Path to the configuration file (default: "+generator.DefaultConfigFile+" in the working directory when present)")
+	inputFile := flag.String("input", "", "Path or http(s)://, file:// URL of the OpenAPI specification (JSON or YAML)")
 	outputDir := flag.String("output", "", "Path to the output MCP server directory")
 
 	validation := flag.Bool("validation", false, "Enable OpenAPI validation")
-	packageName := flag.String("package", "mcpgen", "Generated package name")
+	packageName := flag.String("package", generator.DefaultPackageName, "Generated package name")
 	includes := flag.String("includes", "", "Comma-separated list of includes for the generated code")
-	
+	cacheDir := flag.String("cache-dir", "", "Directory caching remote specifications and references for offline regeneration")
+	filterFile := flag.String("filter-file", "", "YAML or JSON file with the tool filter (includeTags, excludePaths, ...)")
+	includeTags := flag.String("include-tags", "", "Comma-separated tag patterns of the operations to generate")
+	excludeTags := flag.String("exclude-tags", "", "Comma-separated tag patterns of the operations to skip")
+	includePaths := flag.String("include-paths", "", "Comma-separated path patterns of the operations to generate")
+	excludePaths := flag.String("exclude-paths", "", "Comma-separated path patterns of the operations to skip")
+	includeMethods := flag.String("include-methods", "", "Comma-separated HTTP methods of the operations to generate")
+	excludeMethods := flag.String("exclude-methods", "", "Comma-separated HTTP methods of the operations to skip")
+	includeOperations := flag.String("include-operations", "", "Comma-separated operationId patterns of the operations to generate")
+	excludeOperations := flag.String("exclude-operations", "", "Comma-separated operationId patterns of the operations to skip")
+	resources := flag.String("resources", "", "Comma-separated parts of the specification exposed as MCP resources: 'operations' for GET operations with only path parameters, 'schemas' for the component schemas")
+	resourceScheme := flag.String("resource-scheme", "", "URI scheme of the generated resources (default: "+converter.DefaultResourceScheme+")")
+	prompts := flag.String("prompts", "", "Generate prompts explaining how to call the tools: 'tool' for one prompt per tool or 'tag' for one per operation tag")
+	sharedDefinitions := flag.Bool("shared-definitions", false, "Reference the component schemas used by a tool from a $defs section of its schemas instead of inlining them")
+	flattenBody := flag.Bool("flatten-body", false, "Expose the properties of object request bodies as tool arguments instead of a single body argument")
+	schemaBudget := flag.Int("schema-budget", 0, "Maximum size in bytes of the tool input schemas, larger schemas are simplified (default: no limit)")
+	contentTypes := flag.String("content-types", "", "Comma-separated preference order of request body content types, e.g. 'application/json,multipart/form-data' (default: JSON first, then forms, text and anything else)")
+	protocolErrors := flag.String("protocol-errors", "", "Comma-separated upstream status codes, or ranges such as 5XX, returned by proxy handlers as protocol errors instead of tool errors")
+	timeout := flag.String("timeout", "", "Timeout of a tool call to the upstream API, retries included, e.g. '30s' (default: 30s)")
+	maxAttempts := flag.Int("max-attempts", 0, "Attempts of a failed upstream request including the first one, 1 disables retries (default: 3)")
+	rateLimit := flag.Float64("rate-limit", 0, "Requests per second sent to the upstream API by all tools together (default: no limit)")
+	handlers := flag.String("handlers", generator.HandlerModeStub, "Handler generation mode: 'stub' for skeletons or 'proxy' to forward calls to the upstream API")
 
 	// Parse command-line flags
 	flag.Parse()
 
-	// Validate required flags
-	if *inputFile == "" {
-		fmt.Println("Error: input file is required")
-		flag.Usage()
-		os.Exit(1)
+	// Load the configuration file, the default one is optional
+	config := &generator.Config{}
+	configPath := *configFile
+	if configPath == "" {
+		if _, err := os.Stat(generator.DefaultConfigFile); err == nil {
+			configPath = generator.DefaultConfigFile
+		}
+	}
+	if configPath != "" {
+		var err error
+		config, err = generator.LoadConfig(configPath)
+		if err != nil {
+			fmt.Printf("Error loading config: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Flags given on the command line override the configuration file
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "input":
+			config.Input = *inputFile
+		case "output":
+			config.Output = *outputDir
+		case "validation":
+			config.Validation = *validation
+		case "package":
+			config.Package = *packageName
+		case "includes":
+			config.Includes = splitList(*includes)
+		case "cache-dir":
+			config.CacheDir = *cacheDir
+		case "handlers":
+			config.Handlers = *handlers
+		case "prompts":
+			config.Prompts = *prompts
+		case "shared-definitions":
+			config.SharedDefinitions = *sharedDefinitions
+		case "flatten-body":
+			config.FlattenBody = *flattenBody
+		case "schema-budget":
+			config.SchemaBudget = *schemaBudget
+		case "content-types":
+			config.ContentTypes = splitList(*contentTypes)
+		case "protocol-errors":
+			config.ProtocolErrors = splitList(*protocolErrors)
+		case "timeout":
+			config.Transport.Timeout = *timeout
+		case "max-attempts":
+			config.Transport.Retry.MaxAttempts = *maxAttempts
+		case "rate-limit":
+			config.Transport.RateLimit.RequestsPerSecond = *rateLimit
+		case "resource-scheme":
+			config.Resources.Scheme = *resourceScheme
+		}
+	})
+
+	for _, kind := range splitList(*resources) {
+		switch kind {
+		case "operations":
+			config.Resources.Operations = true
+		case "schemas":
+			config.Resources.Schemas = true
+		default:
+			fmt.Printf("Error: unknown resources kind %q (must be 'operations' or 'schemas')\n", kind)
+			os.Exit(1)
+		}
+	}
+
+	// Filter patterns from the filter file and flags are added to the configured ones
+	if *filterFile != "" {
+		filter, err := converter.LoadToolFilter(*filterFile)
+		if err != nil {
+			fmt.Printf("Error loading filter file: %v\n", err)
+			os.Exit(1)
+		}
+		appendFilter(&config.Filters, filter)
 	}
+	appendFilter(&config.Filters, &converter.ToolFilter{
+		IncludeTags:         splitList(*includeTags),
+		ExcludeTags:         splitList(*excludeTags),
+		IncludePaths:        splitList(*includePaths),
+		ExcludePaths:        splitList(*excludePaths),
+		IncludeMethods:      splitList(*includeMethods),
+		ExcludeMethods:      splitList(*excludeMethods),
+		IncludeOperationIDs: splitList(*includeOperations),
+		ExcludeOperationIDs: splitList(*excludeOperations),
+	})
 
-	if *outputDir == "" {
-		fmt.Println("Error: output directory is required")
+	config.SetDefaults()
+	if err := config.Validate(); err != nil {
+		fmt.Printf("Error: %v\n", err)
 		flag.Usage()
 		os.Exit(1)
 	}
 
 	// Create the output directory if it doesn't exist
-	if *outputDir != "" && *outputDir != "." {
-		err := os.MkdirAll(*outputDir, 0755)
+	if config.Output != "." {
+		err := os.MkdirAll(config.Output, 0755)
 		if err != nil {
-			fmt.Printf("Error creating output directory '%s': %v\n", *outputDir, err)
+			fmt.Printf("Error creating output directory '%s': %v\n", config.Output, err)
 			os.Exit(1)
 		}
 	}
 
-	generator, err := generator.NewGenerator(*inputFile, *validation, *packageName, *outputDir)
+	generator, err := generator.NewGeneratorFromConfig(config)
 	if err != nil {
 		fmt.Printf("Error creating generator: %v\n", err)
 		os.Exit(1)
 	}
 
 	// Generate the HTTP CLIENT
-	if *includes != "" {
-		err = generator.GenerateHTTPClient(strings.Split(*includes, ","))
+	if len(config.Includes) > 0 {
+		err = generator.GenerateHTTPClient(config.Includes)
 		if err != nil {
 			fmt.Printf("Error generating HTTP client: %v\n", err)
 			os.Exit(1)
@@ -67,5 +173,28 @@ func main() {
 		os.Exit(1)
 	}
 
-	fmt.Printf("Successfully converted OpenAPI specification to MCP: %s\n", *outputDir)
+	fmt.Printf("Successfully converted OpenAPI specification to MCP: %s\n", config.Output)
+}
+
+// splitList splits a comma-separated flag value, ignoring empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// appendFilter adds the patterns of a filter to another one
+func appendFilter(dst, src *converter.ToolFilter) {
+	dst.IncludeTags = append(dst.IncludeTags, src.IncludeTags...)
+	dst.ExcludeTags = append(dst.ExcludeTags, src.ExcludeTags...)
+	dst.IncludePaths = append(dst.IncludePaths, src.IncludePaths...)
+	dst.ExcludePaths = append(dst.ExcludePaths, src.ExcludePaths...)
+	dst.IncludeMethods = append(dst.IncludeMethods, src.IncludeMethods...)
+	dst.ExcludeMethods = append(dst.ExcludeMethods, src.ExcludeMethods...)
+	dst.IncludeOperationIDs = append(dst.IncludeOperationIDs, src.IncludeOperationIDs...)
+	dst.ExcludeOperationIDs = append(dst.ExcludeOperationIDs, src.ExcludeOperationIDs...)
 }
@@ -0,0 +1,77 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// deriveAnnotations returns the annotations implied by the HTTP semantics of a method.
+// Safe methods are read-only, DELETE is destructive and PUT and DELETE are idempotent.
+// Every tool calls a remote API so it interacts with an open world.
+func deriveAnnotations(method string) ToolAnnotations {
+	annotations := ToolAnnotations{OpenWorldHint: boolPtr(true)}
+
+	switch strings.ToUpper(method) {
+	case "GET", "HEAD", "OPTIONS", "TRACE":
+		annotations.ReadOnlyHint = boolPtr(true)
+		annotations.IdempotentHint = boolPtr(true)
+	case "DELETE":
+		annotations.ReadOnlyHint = boolPtr(false)
+		annotations.DestructiveHint = boolPtr(true)
+		annotations.IdempotentHint = boolPtr(true)
+	case "PUT":
+		annotations.ReadOnlyHint = boolPtr(false)
+		annotations.IdempotentHint = boolPtr(true)
+	case "POST", "PATCH":
+		annotations.ReadOnlyHint = boolPtr(false)
+		annotations.IdempotentHint = boolPtr(false)
+	}
+	return annotations
+}
+
+// applyAnnotationsExtension overrides annotations with the values set in x-mcp-annotations
+func applyAnnotationsExtension(annotations *ToolAnnotations, extensions map[string]any) error {
+	value, ok := extensions[annotationsExtension]
+	if !ok {
+		return nil
+	}
+	overrides, ok := value.(map[string]any)
+	if !ok {
+		return fmt.Errorf("%s must be an object, got %v", annotationsExtension, value)
+	}
+
+	for key, value := range overrides {
+		if key == "title" {
+			title, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("%s.title must be a string, got %v", annotationsExtension, value)
+			}
+			annotations.Title = title
+			continue
+		}
+
+		var hint **bool
+		switch key {
+		case "readOnlyHint":
+			hint = &annotations.ReadOnlyHint
+		case "destructiveHint":
+			hint = &annotations.DestructiveHint
+		case "idempotentHint":
+			hint = &annotations.IdempotentHint
+		case "openWorldHint":
+			hint = &annotations.OpenWorldHint
+		default:
+			return fmt.Errorf("unknown %s field %q", annotationsExtension, key)
+		}
+		b, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("%s.%s must be a boolean, got %v", annotationsExtension, key, value)
+		}
+		*hint = boolPtr(b)
+	}
+	return nil
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,97 @@
+package converter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDeriveAnnotations(t *testing.T) {
+	tests := []struct {
+		method                            string
+		readOnly, destructive, idempotent *bool
+	}{
+		{"GET", boolPtr(true), nil, boolPtr(true)},
+		{"HEAD", boolPtr(true), nil, boolPtr(true)},
+		{"POST", boolPtr(false), nil, boolPtr(false)},
+		{"PUT", boolPtr(false), nil, boolPtr(true)},
+		{"PATCH", boolPtr(false), nil, boolPtr(false)},
+		{"DELETE", boolPtr(false), boolPtr(true), boolPtr(true)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			got := deriveAnnotations(tt.method)
+			want := ToolAnnotations{
+				ReadOnlyHint:    tt.readOnly,
+				DestructiveHint: tt.destructive,
+				IdempotentHint:  tt.idempotent,
+				OpenWorldHint:   boolPtr(true),
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("deriveAnnotations(%s) = %+v, want %+v", tt.method, got, want)
+			}
+		})
+	}
+}
+
+const annotationsSpec = `openapi: 3.0.3
+info:
+  title: Annotations API
+  version: "1.0.0"
+paths:
+  /todos/{todoId}:
+    delete:
+      operationId: archiveTodo
+      summary: Archive a todo
+      x-mcp-annotations:
+        destructiveHint: false
+        openWorldHint: false
+      parameters:
+        - name: todoId
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "204":
+          description: Archived
+`
+
+func TestConverter_Annotations(t *testing.T) {
+	tool := convertExtensionsSpec(t, annotationsSpec)
+
+	want := ToolAnnotations{
+		Title:           "Archive a todo",
+		ReadOnlyHint:    boolPtr(false),
+		DestructiveHint: boolPtr(false),
+		IdempotentHint:  boolPtr(true),
+		OpenWorldHint:   boolPtr(false),
+	}
+	if !reflect.DeepEqual(tool.Annotations, want) {
+		t.Errorf("Annotations = %+v, want %+v", tool.Annotations, want)
+	}
+}
+
+func TestConverter_Annotations_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr string
+	}{
+		{"unknown field", "readonlyHint: true", `unknown x-mcp-annotations field "readonlyHint"`},
+		{"not a boolean", "destructiveHint: maybe", "x-mcp-annotations.destructiveHint must be a boolean"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := strings.Replace(annotationsSpec, "destructiveHint: false", tt.value, 1)
+			parser := NewParser(false)
+			if err := parser.Parse([]byte(spec)); err != nil {
+				t.Fatalf("failed to parse OpenAPI: %v", err)
+			}
+			_, err := NewConverter(parser).Convert()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Convert() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
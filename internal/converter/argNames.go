@@ -0,0 +1,124 @@
+package converter
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxArgNameLength is the longest argument name accepted by MCP clients restricting property names
+const maxArgNameLength = 64
+
+// invalidArgNameChars matches the characters MCP clients restricting property names reject
+var invalidArgNameChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// sourcePriority orders the argument sources, the first ones keep their name on collision
+var sourcePriority = map[string]int{"path": 0, "query": 1, "header": 2, "cookie": 3, "body": 4, "pagination": 5}
+
+// sanitizeArgName makes a parameter name a valid property name: letters, digits, _ and -, at most 64 characters.
+// Runs of other characters become a single _, e.g. "filter[status]" becomes "filter_status".
+func sanitizeArgName(name string) string {
+	sanitized := strings.Trim(invalidArgNameChars.ReplaceAllString(name, "_"), "_")
+	if sanitized == "" {
+		sanitized = "arg"
+	}
+	if len(sanitized) > maxArgNameLength {
+		sanitized = sanitized[:maxArgNameLength]
+	}
+	return sanitized
+}
+
+// resolveArgNames sanitizes the argument names and gives every argument a distinct name.
+// On collision, arguments keep their name in the order path, query, header, cookie then body,
+// the others are prefixed with their source, e.g. "query_id" next to the "id" path parameter,
+// then numbered when still colliding. The original names are kept in ParamName.
+func resolveArgNames(args []Arg) {
+	order := make([]int, len(args))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return sourcePriority[args[order[i]].Source] < sourcePriority[args[order[j]].Source]
+	})
+
+	// Arguments whose name is free keep it first, so that prefixed names cannot take it
+	used := make(map[string]bool, len(args))
+	var colliding []int
+	for _, i := range order {
+		name := sanitizeArgName(args[i].Name)
+		if used[name] {
+			colliding = append(colliding, i)
+			continue
+		}
+		used[name] = true
+		args[i].Name = name
+	}
+
+	for _, i := range colliding {
+		arg := &args[i]
+		base := sanitizeArgName(arg.Source + "_" + arg.Name)
+		name := base
+		for n := 2; used[name]; n++ {
+			suffix := "_" + strconv.Itoa(n)
+			name = base[:min(len(base), maxArgNameLength-len(suffix))] + suffix
+		}
+		used[name] = true
+		arg.Name = name
+	}
+}
+
+// renameExampleArguments rewrites complete sets of example arguments written with the parameter names:
+// body properties move to the flattened arguments filling them and renamed parameters take their argument name.
+// Parameter names shared by several arguments are ambiguous and left as they are.
+func renameExampleArguments(examples []interface{}, args []Arg) []interface{} {
+	if len(examples) == 0 {
+		return examples
+	}
+
+	params := make(map[string]int)
+	for _, arg := range args {
+		if arg.ParamName != "" {
+			params[arg.ParamName]++
+		}
+	}
+	renamed := make(map[string]string)
+	properties := make(map[string]string)
+	for _, arg := range args {
+		if arg.ParamName != "" && arg.ParamName != arg.Name && params[arg.ParamName] == 1 {
+			renamed[arg.ParamName] = arg.Name
+		}
+		if arg.BodyProperty != "" {
+			properties[arg.BodyProperty] = arg.Name
+		}
+	}
+	if len(renamed) == 0 && len(properties) == 0 {
+		return examples
+	}
+
+	rewritten := make([]interface{}, 0, len(examples))
+	for _, example := range examples {
+		arguments, ok := example.(map[string]interface{})
+		if !ok {
+			rewritten = append(rewritten, example)
+			continue
+		}
+		result := make(map[string]interface{}, len(arguments))
+		for name, value := range arguments {
+			if body, ok := value.(map[string]interface{}); ok && name == "body" && len(properties) > 0 {
+				for property, propertyValue := range body {
+					if argName, ok := properties[property]; ok {
+						result[argName] = propertyValue
+					}
+				}
+				continue
+			}
+			if argName, ok := renamed[name]; ok {
+				name = argName
+			}
+			result[name] = value
+		}
+		rewritten = append(rewritten, result)
+	}
+	return rewritten
+}
@@ -0,0 +1,97 @@
+package converter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_sanitizeArgName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"todoId", "todoId"},
+		{"X-Request-ID", "X-Request-ID"},
+		{"filter[status]", "filter_status"},
+		{"user.address.city", "user_address_city"},
+		{"session id", "session_id"},
+		{"$top", "top"},
+		{"[]", "arg"},
+		{strings.Repeat("a", 70), strings.Repeat("a", 64)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitizeArgName(tt.name); got != tt.want {
+				t.Errorf("sanitizeArgName(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_resolveArgNames(t *testing.T) {
+	tests := []struct {
+		name string
+		args []Arg
+		want []string
+	}{
+		{
+			name: "distinct names",
+			args: []Arg{{Name: "id", Source: "path"}, {Name: "limit", Source: "query"}, {Name: "body", Source: "body"}},
+			want: []string{"id", "limit", "body"},
+		},
+		{
+			name: "path keeps its name",
+			args: []Arg{{Name: "id", Source: "query"}, {Name: "id", Source: "header"}, {Name: "id", Source: "path"}},
+			want: []string{"query_id", "header_id", "id"},
+		},
+		{
+			name: "flattened body property",
+			args: []Arg{{Name: "id", Source: "path"}, {Name: "id", Source: "body", BodyProperty: "id"}},
+			want: []string{"id", "body_id"},
+		},
+		{
+			name: "sanitized names collide",
+			args: []Arg{{Name: "filter[a]", Source: "query"}, {Name: "filter.a", Source: "query"}, {Name: "query_filter_a", Source: "query"}},
+			want: []string{"filter_a", "query_filter_a_2", "query_filter_a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolveArgNames(tt.args)
+			var got []string
+			for _, arg := range tt.args {
+				got = append(got, arg.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("names = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_renameExampleArguments(t *testing.T) {
+	args := []Arg{
+		{Name: "id", ParamName: "id", Source: "path"},
+		{Name: "query_id", ParamName: "id", Source: "query"},
+		{Name: "filter_status", ParamName: "filter[status]", Source: "query"},
+		{Name: "body_id", Source: "body", BodyProperty: "id"},
+		{Name: "name", Source: "body", BodyProperty: "name"},
+	}
+	examples := []interface{}{
+		map[string]interface{}{
+			"id":             "7",
+			"filter[status]": "open",
+			"body":           map[string]interface{}{"id": "8", "name": "Pen"},
+		},
+		"not an object",
+	}
+
+	want := []interface{}{
+		map[string]interface{}{"id": "7", "filter_status": "open", "body_id": "8", "name": "Pen"},
+		"not an object",
+	}
+	if got := renameExampleArguments(examples, args); !reflect.DeepEqual(got, want) {
+		t.Errorf("renameExampleArguments() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,96 @@
+package converter
+
+import (
+	"fmt"
+	"mime"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// DefaultContentTypes is the preference order of request body content types when none is configured:
+// JSON first, then forms, plain text and anything else
+var DefaultContentTypes = []string{
+	"application/json",
+	"*/*+json",
+	"application/x-www-form-urlencoded",
+	"multipart/form-data",
+	"text/plain",
+	"*/*",
+}
+
+// contentTypeArgName is the argument selecting the content type of request bodies offering several
+const contentTypeArgName = "contentType"
+
+// SetContentTypes sets the preference order of request body content types, given as media types or
+// patterns such as application/* or */*+json. Content types matching none of them are dropped, unless
+// a request body offers no other. Nil restores DefaultContentTypes.
+func (c *Converter) SetContentTypes(preference []string) error {
+	for _, pattern := range preference {
+		if _, err := path.Match(pattern, ""); err != nil || strings.Count(pattern, "/") != 1 {
+			return fmt.Errorf("invalid content type %q", pattern)
+		}
+	}
+	c.contentTypes = preference
+	return nil
+}
+
+// sortContentTypes returns the content types of a request body that are kept, the preferred one first.
+// Content types of the same preference are sorted by name so that generation is deterministic.
+func (c *Converter) sortContentTypes(content openapi3.Content) []string {
+	preference := c.contentTypes
+	if len(preference) == 0 {
+		preference = DefaultContentTypes
+	}
+	rank := func(contentType string) int {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			mediaType = strings.ToLower(contentType)
+		}
+		for i, pattern := range preference {
+			if matched, _ := path.Match(strings.ToLower(pattern), mediaType); matched {
+				return i
+			}
+		}
+		return len(preference)
+	}
+
+	contentTypes := sortedContentTypes(content)
+	sort.SliceStable(contentTypes, func(i, j int) bool {
+		return rank(contentTypes[i]) < rank(contentTypes[j])
+	})
+
+	// Content types matching no preference sort last, they are dropped when a preferred one is offered
+	if len(contentTypes) > 0 && rank(contentTypes[0]) < len(preference) {
+		for i, contentType := range contentTypes {
+			if rank(contentType) == len(preference) {
+				return contentTypes[:i]
+			}
+		}
+	}
+	return contentTypes
+}
+
+// contentTypeArg returns the argument letting the model pick the content type of a request body
+// offering several, nil otherwise. It defaults to the preferred content type.
+func contentTypeArg(body Arg, contentTypes []string) *Arg {
+	var offered []interface{}
+	for _, contentType := range contentTypes {
+		if _, ok := body.ContentTypes[contentType]; ok {
+			offered = append(offered, contentType)
+		}
+	}
+	if len(offered) < 2 {
+		return nil
+	}
+	return &Arg{
+		Name:        contentTypeArgName,
+		ParamName:   "Content-Type",
+		Description: "Content type of the request body, the body must match the schema given for this content type",
+		Source:      "header",
+		Style:       "simple",
+		Schema:      &Schema{Types: []string{"string"}, Enum: offered, Default: offered[0]},
+	}
+}
@@ -0,0 +1,150 @@
+package converter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestConverter_sortContentTypes(t *testing.T) {
+	content := openapi3.Content{
+		"application/xml":                   &openapi3.MediaType{},
+		"application/x-www-form-urlencoded": &openapi3.MediaType{},
+		"application/vnd.api+json":          &openapi3.MediaType{},
+		"application/json; charset=utf-8":   &openapi3.MediaType{},
+		"multipart/form-data":               &openapi3.MediaType{},
+	}
+
+	tests := []struct {
+		name       string
+		preference []string
+		want       []string
+	}{
+		{
+			name: "default",
+			want: []string{"application/json; charset=utf-8", "application/vnd.api+json", "application/x-www-form-urlencoded", "multipart/form-data", "application/xml"},
+		},
+		{
+			name:       "configured",
+			preference: []string{"multipart/*", "application/xml"},
+			want:       []string{"multipart/form-data", "application/xml"},
+		},
+		{
+			name:       "none preferred",
+			preference: []string{"text/plain"},
+			want:       []string{"application/json; charset=utf-8", "application/vnd.api+json", "application/x-www-form-urlencoded", "application/xml", "multipart/form-data"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Converter{}
+			if err := c.SetContentTypes(tt.preference); err != nil {
+				t.Fatalf("SetContentTypes() error = %v", err)
+			}
+			if got := c.sortContentTypes(content); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sortContentTypes() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if err := (&Converter{}).SetContentTypes([]string{"json"}); err == nil {
+		t.Error("SetContentTypes() error = nil, want error for a pattern without a subtype")
+	}
+}
+
+const contentTypesSpec = `openapi: 3.0.3
+info:
+  title: Content API
+  version: "1.0.0"
+paths:
+  /notes:
+    post:
+      operationId: createNote
+      requestBody:
+        content:
+          application/xml:
+            schema:
+              $ref: "#/components/schemas/Note"
+          application/x-www-form-urlencoded:
+            schema:
+              $ref: "#/components/schemas/Note"
+          application/json:
+            schema:
+              $ref: "#/components/schemas/Note"
+      responses:
+        "204":
+          description: Created
+components:
+  schemas:
+    Note:
+      type: object
+      properties:
+        text:
+          type: string
+`
+
+func TestConverter_ContentTypeArgument(t *testing.T) {
+	tests := []struct {
+		name         string
+		preference   []string
+		wantHeader   string
+		wantEnum     []interface{}
+		wantArgument bool
+	}{
+		{
+			name:         "default",
+			wantHeader:   "application/json",
+			wantEnum:     []interface{}{"application/json", "application/x-www-form-urlencoded", "application/xml"},
+			wantArgument: true,
+		},
+		{
+			name:       "single preferred",
+			preference: []string{"application/x-www-form-urlencoded"},
+			wantHeader: "application/x-www-form-urlencoded",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := NewParser(false)
+			if err := parser.Parse([]byte(contentTypesSpec)); err != nil {
+				t.Fatalf("failed to parse OpenAPI: %v", err)
+			}
+			converter := NewConverter(parser)
+			if err := converter.SetContentTypes(tt.preference); err != nil {
+				t.Fatalf("SetContentTypes() error = %v", err)
+			}
+			config, err := converter.Convert()
+			if err != nil {
+				t.Fatalf("Convert failed: %v", err)
+			}
+			tool := config.Tools[0]
+
+			if header := tool.RequestTemplate.Headers[0]; header.Value != tt.wantHeader {
+				t.Errorf("Content-Type = %q, want %q", header.Value, tt.wantHeader)
+			}
+			var selector *Arg
+			for i := range tool.Args {
+				if tool.Args[i].Name == contentTypeArgName {
+					selector = &tool.Args[i]
+				}
+			}
+			if (selector != nil) != tt.wantArgument {
+				t.Fatalf("contentType argument = %v, want %v", selector != nil, tt.wantArgument)
+			}
+			if selector == nil {
+				if strings.Contains(tool.RawInputSchema, "anyOf") {
+					t.Errorf("input schema offers several bodies: %s", tool.RawInputSchema)
+				}
+				return
+			}
+			if !reflect.DeepEqual(selector.Schema.Enum, tt.wantEnum) || selector.Schema.Default != tt.wantHeader {
+				t.Errorf("contentType enum = %v, default = %v", selector.Schema.Enum, selector.Schema.Default)
+			}
+			if selector.Source != "header" || selector.ParamName != "Content-Type" {
+				t.Errorf("contentType argument is sent as %s %q, want the Content-Type header", selector.Source, selector.ParamName)
+			}
+		})
+	}
+}
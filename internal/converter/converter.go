@@ -3,17 +3,30 @@ package converter
 import (
 	"fmt"
 	"sort"
+
+	"github.com/getkin/kin-openapi/openapi3"
 )
 
 // Converter represents an OpenAPI to MCP converter
 type Converter struct {
-	parser  *Parser
-	options ConvertOptions
-}
+	parser    *Parser
+	options   ConvertOptions
+	filter    *toolFilter
+	resources ResourceOptions
 
+	sharedDefinitions bool
+	flattenBody       bool
+	pagination        bool
+	definitions       map[string]*Schema        // Converted component schemas by name
+	converting        map[*openapi3.Schema]bool // Schemas being converted, to detect recursion
+	documenting       map[*openapi3.Schema]bool // Schemas being documented in Markdown, to detect recursion
+
+	schemaBudget SchemaBudget
+	contentTypes []string // Preference order of request body content types, DefaultContentTypes when empty
+}
 
 type ConverterInterface interface {
-    Convert() (*MCPConfig, error)
+	Convert() (*MCPConfig, error)
 }
 
 // NewConverter creates a new OpenAPI to MCP converter
@@ -26,7 +39,6 @@ func NewConverter(parser *Parser) *Converter {
 	}
 }
 
-
 // Convert converts an OpenAPI document to an MCP configuration
 func (c *Converter) Convert() (*MCPConfig, error) {
 	if c.parser.GetDocument() == nil {
@@ -36,7 +48,8 @@ func (c *Converter) Convert() (*MCPConfig, error) {
 	// Create the MCP configuration
 	config := &MCPConfig{
 		Server: ServerConfig{
-			Config: c.options.ServerConfig,
+			Config:          c.options.ServerConfig,
+			SecuritySchemes: c.convertSecuritySchemes(),
 		},
 		Tools: []Tool{},
 	}
@@ -45,18 +58,47 @@ func (c *Converter) Convert() (*MCPConfig, error) {
 	for path, pathItem := range c.parser.GetPaths() {
 		operations := getOperations(pathItem)
 		for method, operation := range operations {
+			if !c.includeOperation(path, method, operation) {
+				continue
+			}
+
 			tool, err := c.convertOperation(path, method, operation)
 			if err != nil {
 				return nil, fmt.Errorf("failed to convert operation %s %s: %w", method, path, err)
 			}
+			if resource := c.operationResource(path, tool); resource != nil {
+				config.Resources = append(config.Resources, *resource)
+				continue
+			}
 			config.Tools = append(config.Tools, *tool)
 		}
 	}
 
-	// Sort tools by name for consistent output
+	// Sort tools and resources by name for consistent output
 	sort.Slice(config.Tools, func(i, j int) bool {
 		return config.Tools[i].Name < config.Tools[j].Name
 	})
+	sort.Slice(config.Resources, func(i, j int) bool {
+		return config.Resources[i].Name < config.Resources[j].Name
+	})
+
+	schemaResources, err := c.schemaResources()
+	if err != nil {
+		return nil, err
+	}
+	config.Resources = append(config.Resources, schemaResources...)
 
 	return config, nil
 }
+
+// includeOperation reports whether an operation is converted into a tool
+func (c *Converter) includeOperation(path, method string, operation *openapi3.Operation) bool {
+	if isExcluded(operation) {
+		return false
+	}
+	if c.filter == nil {
+		return true
+	}
+	operationID := c.parser.GetOperationID(path, method, operation)
+	return c.filter.allows(path, method, operationID, operation.Tags)
+}
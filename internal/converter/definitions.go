@@ -0,0 +1,181 @@
+package converter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// componentSchemaPrefix starts the references to the component schemas of the document
+const componentSchemaPrefix = "#/components/schemas/"
+
+// definitionsKeyword holds the referenced component schemas in the generated JSON schemas
+const definitionsKeyword = "$defs"
+
+// SetSharedDefinitions makes the converted schemas reference the component schemas they use,
+// which are emitted once per tool schema under $defs, instead of inlining them at every use.
+// Recursive component schemas cannot be inlined and are always referenced.
+func (c *Converter) SetSharedDefinitions(enabled bool) {
+	c.sharedDefinitions = enabled
+}
+
+// applySchemaRef converts a possibly referenced schema.
+// A component schema becomes a reference when definitions are shared or when it is being converted, i.e. recursive.
+func (c *Converter) applySchemaRef(schemaRef *openapi3.SchemaRef) (*Schema, error) {
+	if schemaRef == nil || schemaRef.Value == nil {
+		return nil, fmt.Errorf("cannot apply metadata to nil schema")
+	}
+
+	name := componentName(schemaRef.Ref)
+	recursive := c.converting[schemaRef.Value]
+	if name == "" || (!c.sharedDefinitions && !recursive) {
+		if recursive {
+			// A cycle through schemas that are not components cannot be referenced, it is cut with an empty schema
+			return &Schema{Description: "Recursive schema"}, nil
+		}
+		return c.applySchema(schemaRef.Value)
+	}
+
+	if err := c.defineComponent(name, schemaRef.Value); err != nil {
+		return nil, err
+	}
+	return &Schema{Ref: name}, nil
+}
+
+// defineComponent converts a component schema into the definitions, once
+func (c *Converter) defineComponent(name string, schema *openapi3.Schema) error {
+	if _, ok := c.definitions[name]; ok {
+		return nil
+	}
+	if c.definitions == nil {
+		c.definitions = make(map[string]*Schema)
+	}
+
+	// The placeholder ends the recursion of references to the component from its own schema.
+	// The definition stands on its own, the schemas being converted around the reference are not part of it.
+	c.definitions[name] = &Schema{}
+	converting := c.converting
+	c.converting = nil
+	definition, err := c.applySchema(schema)
+	c.converting = converting
+	if err != nil {
+		delete(c.definitions, name)
+		return fmt.Errorf("failed to convert component schema %s: %w", name, err)
+	}
+	c.definitions[name] = definition
+	return nil
+}
+
+// componentName returns the name of the component schema a reference points to, empty for other references
+func componentName(ref string) string {
+	index := strings.Index(ref, componentSchemaPrefix)
+	if index < 0 {
+		return ""
+	}
+	name := ref[index+len(componentSchemaPrefix):]
+	if strings.Contains(name, "/") {
+		return ""
+	}
+	return name
+}
+
+// referencedDefinitions returns the definitions the schemas reference, directly or through other definitions
+func (c *Converter) referencedDefinitions(schemas ...*Schema) map[string]*Schema {
+	found := make(map[string]*Schema)
+	for _, schema := range schemas {
+		c.collectReferences(schema, found)
+	}
+	if len(found) == 0 {
+		return nil
+	}
+	return found
+}
+
+// argSchemas returns the schemas of the arguments, including every content type of request bodies
+func argSchemas(args []Arg) []*Schema {
+	var schemas []*Schema
+	for _, arg := range args {
+		schemas = append(schemas, arg.Schema)
+		for _, schema := range arg.ContentTypes {
+			schemas = append(schemas, schema)
+		}
+	}
+	return schemas
+}
+
+// collectReferences adds the definitions referenced by a schema to found
+func (c *Converter) collectReferences(schema *Schema, found map[string]*Schema) {
+	if schema == nil {
+		return
+	}
+	if schema.Ref != "" {
+		if _, ok := found[schema.Ref]; ok {
+			return
+		}
+		definition := c.definitions[schema.Ref]
+		found[schema.Ref] = definition
+		c.collectReferences(definition, found)
+		return
+	}
+
+	for _, sub := range schemaChildren(schema) {
+		c.collectReferences(sub, found)
+	}
+}
+
+// schemaChildren returns the sub-schemas of a schema
+func schemaChildren(schema *Schema) []*Schema {
+	var children []*Schema
+	children = append(children, schema.OneOf...)
+	children = append(children, schema.AnyOf...)
+	children = append(children, schema.AllOf...)
+	if schema.Not != nil {
+		children = append(children, schema.Not)
+	}
+	if schema.Array != nil && schema.Array.Items != nil {
+		children = append(children, schema.Array.Items)
+	}
+	if schema.Object != nil {
+		names := make([]string, 0, len(schema.Object.Properties))
+		for name := range schema.Object.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			children = append(children, schema.Object.Properties[name])
+		}
+		if schema.Object.AdditionalProperties != nil {
+			children = append(children, schema.Object.AdditionalProperties)
+		}
+	}
+	return children
+}
+
+// addDefinitions adds the definitions to a root JSON schema under $defs
+func addDefinitions(root map[string]interface{}, definitions map[string]*Schema) error {
+	if len(definitions) == 0 {
+		return nil
+	}
+	defs := make(map[string]interface{}, len(definitions))
+	for name, definition := range definitions {
+		definitionMap, err := schemaToDraft7Map(definition)
+		if err != nil {
+			return fmt.Errorf("failed to convert definition %s: %w", name, err)
+		}
+		defs[name] = definitionMap
+	}
+	root[definitionsKeyword] = defs
+	return nil
+}
+
+// resolveDefinition returns the definition a schema references, or the schema itself
+func resolveDefinition(schema *Schema, definitions map[string]*Schema) *Schema {
+	if schema != nil && schema.Ref != "" {
+		if definition, ok := definitions[schema.Ref]; ok && definition != nil {
+			return definition
+		}
+	}
+	return schema
+}
@@ -0,0 +1,176 @@
+package converter
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const definitionsSpec = `openapi: 3.0.3
+info:
+  title: Definitions API
+  version: "1.0.0"
+paths:
+  /nodes:
+    post:
+      operationId: createNode
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: "#/components/schemas/Node"
+      responses:
+        "201":
+          description: Created
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Node"
+  /people:
+    post:
+      operationId: createPerson
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                home:
+                  $ref: "#/components/schemas/Address"
+                work:
+                  $ref: "#/components/schemas/Address"
+      responses:
+        "204":
+          description: Created
+components:
+  schemas:
+    Address:
+      type: object
+      properties:
+        street:
+          type: string
+        country:
+          $ref: "#/components/schemas/Country"
+    Country:
+      type: string
+      enum: [FR, DZ]
+    Node:
+      type: object
+      properties:
+        name:
+          type: string
+        children:
+          type: array
+          items:
+            $ref: "#/components/schemas/Node"
+`
+
+// convertDefinitionsSpec converts definitionsSpec and returns the input schemas of the tools by name
+func convertDefinitionsSpec(t *testing.T, shared bool) (map[string]map[string]any, *MCPConfig) {
+	t.Helper()
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(definitionsSpec)); err != nil {
+		t.Fatalf("failed to parse OpenAPI: %v", err)
+	}
+	converter := NewConverter(parser)
+	converter.SetSharedDefinitions(shared)
+	config, err := converter.Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	schemas := make(map[string]map[string]any)
+	for _, tool := range config.Tools {
+		var schema map[string]any
+		if err := json.Unmarshal([]byte(tool.RawInputSchema), &schema); err != nil {
+			t.Fatalf("%s: invalid input schema: %v", tool.Name, err)
+		}
+		schemas[tool.Name] = schema
+	}
+	return schemas, config
+}
+
+// definitionNames returns the sorted names under $defs of a schema
+func definitionNames(schema map[string]any) string {
+	defs, _ := schema["$defs"].(map[string]any)
+	names := make([]string, 0, len(defs))
+	for name := range defs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+func TestConverter_Definitions(t *testing.T) {
+	tests := []struct {
+		name   string
+		shared bool
+		defs   map[string]string // Definitions of the input schema by tool
+		home   string            // Reference of the home property, empty when inlined
+	}{
+		{
+			name: "inlined",
+			defs: map[string]string{"createNode": "Node", "createPerson": ""},
+		},
+		{
+			name:   "shared",
+			shared: true,
+			defs:   map[string]string{"createNode": "Node", "createPerson": "Address,Country"},
+			home:   "#/$defs/Address",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schemas, config := convertDefinitionsSpec(t, tt.shared)
+			for tool, want := range tt.defs {
+				if got := definitionNames(schemas[tool]); got != want {
+					t.Errorf("%s: $defs = %q, want %q", tool, got, want)
+				}
+			}
+
+			body := schemas["createPerson"]["properties"].(map[string]any)["body"].(map[string]any)
+			home := body["properties"].(map[string]any)["home"].(map[string]any)
+			if ref, _ := home["$ref"].(string); ref != tt.home {
+				t.Errorf("home $ref = %q, want %q", ref, tt.home)
+			}
+
+			// The recursion goes through the definition whatever the mode
+			node := schemas["createNode"]["$defs"].(map[string]any)["Node"].(map[string]any)
+			items := node["properties"].(map[string]any)["children"].(map[string]any)["items"].(map[string]any)
+			if items["$ref"] != "#/$defs/Node" {
+				t.Errorf("children items = %v, want a reference to Node", items)
+			}
+
+			for _, tool := range config.Tools {
+				if tool.Name != "createNode" {
+					continue
+				}
+				if tool.Output == nil || !strings.Contains(tool.Output.RawSchema, `"$ref": "#/$defs/Node"`) {
+					t.Errorf("createNode output = %+v, want the recursive Node schema", tool.Output)
+				}
+				markdown := tool.Responses[0].PrependBody
+				if !strings.Contains(markdown, "Recursive: same structure as the enclosing schema above") {
+					t.Errorf("response template does not document the recursion:\n%s", markdown)
+				}
+			}
+		})
+	}
+}
+
+func Test_componentName(t *testing.T) {
+	tests := map[string]string{
+		"#/components/schemas/Todo":            "Todo",
+		"common.yaml#/components/schemas/Tag":  "Tag",
+		"#/components/schemas/Todo/properties": "",
+		"#/components/parameters/limit":        "",
+		"":                                     "",
+	}
+	for ref, want := range tests {
+		if got := componentName(ref); got != want {
+			t.Errorf("componentName(%q) = %q, want %q", ref, got, want)
+		}
+	}
+}
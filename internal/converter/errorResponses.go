@@ -0,0 +1,54 @@
+package converter
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// createErrorDescriptions returns the documented meaning of the error responses of an operation,
+// keyed by status code, by range such as 4XX, or by default. Responses without a description are left out.
+func createErrorDescriptions(operation *openapi3.Operation) map[string]string {
+	if operation == nil || operation.Responses == nil {
+		return nil
+	}
+
+	var descriptions map[string]string
+	for code, responseRef := range operation.Responses.Map() {
+		if responseRef == nil || responseRef.Value == nil || responseRef.Value.Description == nil {
+			continue
+		}
+		// Ranges may be written 4xx or 4XX
+		if code != "default" {
+			code = strings.ToUpper(code)
+		}
+		if !isErrorCode(code) {
+			continue
+		}
+		description := summarizeDescription(*responseRef.Value.Description)
+		if description == "" {
+			continue
+		}
+		if descriptions == nil {
+			descriptions = make(map[string]string)
+		}
+		descriptions[code] = description
+	}
+	return descriptions
+}
+
+// isErrorCode reports whether a response code documents errors: 4xx and 5xx codes and ranges, and default
+func isErrorCode(code string) bool {
+	if code == "default" || code == "4XX" || code == "5XX" {
+		return true
+	}
+	status, err := strconv.Atoi(code)
+	return err == nil && status >= 400
+}
+
+// summarizeDescription keeps the first paragraph of a description on a single line
+func summarizeDescription(description string) string {
+	paragraph, _, _ := strings.Cut(strings.TrimSpace(description), "\n\n")
+	return strings.Join(strings.Fields(paragraph), " ")
+}
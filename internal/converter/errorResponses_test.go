@@ -0,0 +1,83 @@
+package converter
+
+import (
+	"reflect"
+	"testing"
+)
+
+const errorResponsesSpec = `openapi: 3.0.3
+info:
+  title: Error API
+  version: "1.0.0"
+paths:
+  /todos/{id}:
+    get:
+      operationId: getTodo
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: The todo
+        "404":
+          description: |
+            The todo does not exist,
+            check the id.
+
+            Deleted todos are not returned.
+        "422":
+          description: ""
+        4xx:
+          description: Invalid request
+        default:
+          description: Unexpected error
+  /health:
+    get:
+      operationId: health
+      responses:
+        "200":
+          description: OK
+`
+
+func TestConverter_ErrorDescriptions(t *testing.T) {
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(errorResponsesSpec)); err != nil {
+		t.Fatalf("failed to parse OpenAPI: %v", err)
+	}
+	config, err := NewConverter(parser).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	tests := []struct {
+		tool string
+		want map[string]string
+	}{
+		{
+			tool: "getTodo",
+			want: map[string]string{
+				"404":     "The todo does not exist, check the id.",
+				"4XX":     "Invalid request",
+				"default": "Unexpected error",
+			},
+		},
+		{tool: "health"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			for _, tool := range config.Tools {
+				if tool.Name != tt.tool {
+					continue
+				}
+				if !reflect.DeepEqual(tool.Errors, tt.want) {
+					t.Errorf("Errors = %v, want %v", tool.Errors, tt.want)
+				}
+				return
+			}
+			t.Fatalf("tool %s not found", tt.tool)
+		})
+	}
+}
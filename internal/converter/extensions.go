@@ -0,0 +1,134 @@
+package converter
+
+import (
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Vendor extensions tuning what MCP clients see without changing the public API documentation
+const (
+	nameExtension         = "x-mcp-name"          // Operation: tool name exposed to clients
+	descriptionExtension  = "x-mcp-description"   // Operation, parameter, request body, schema: replaces the description
+	hiddenParamsExtension = "x-mcp-hidden-params" // Operation: names of the parameters hidden from clients
+	hiddenExtension       = "x-mcp-hidden"        // Parameter, schema property: hidden from clients
+	examplesExtension     = "x-mcp-examples"      // Operation: example arguments, parameter and schema: example values
+	annotationsExtension  = "x-mcp-annotations"   // Operation: title and hints replacing the ones derived from the method
+	timeoutExtension      = "x-mcp-timeout"       // Operation: timeout of the upstream request, a duration such as 30s or seconds
+	retryExtension        = "x-mcp-retry"         // Operation: retry policy of the upstream request, attempts or an object
+	paginationExtension   = "x-mcp-pagination"    // Operation: pagination replacing the detected one, false to disable it
+)
+
+// extensionString returns a string extension, or an empty string when it is missing or not a string
+func extensionString(extensions map[string]any, name string) string {
+	value, _ := extensions[name].(string)
+	return value
+}
+
+// extensionBool returns a boolean extension, false when it is missing or not a boolean
+func extensionBool(extensions map[string]any, name string) bool {
+	value, _ := extensions[name].(bool)
+	return value
+}
+
+// extensionList returns a list extension, a single value is turned into a list of one
+func extensionList(extensions map[string]any, name string) []any {
+	switch value := extensions[name].(type) {
+	case nil:
+		return nil
+	case []any:
+		return value
+	default:
+		return []any{value}
+	}
+}
+
+// extensionStrings returns a list of strings extension
+func extensionStrings(extensions map[string]any, name string) ([]string, error) {
+	var values []string
+	for _, item := range extensionList(extensions, name) {
+		s, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s must be a list of strings, got %v", name, item)
+		}
+		values = append(values, s)
+	}
+	return values, nil
+}
+
+// applyOperationExtensions applies the x-mcp extensions of an operation to its tool
+func applyOperationExtensions(tool *Tool, operation *openapi3.Operation) {
+	if name := extensionString(operation.Extensions, nameExtension); name != "" {
+		tool.MCPName = name
+	}
+	if description := extensionString(operation.Extensions, descriptionExtension); description != "" {
+		tool.Description = description
+	}
+}
+
+// hideArgs moves the arguments hidden through x-mcp-hidden-params or x-mcp-hidden out of the tool arguments.
+// Required arguments can only be hidden with a schema default, the value sent in their place.
+func hideArgs(tool *Tool, operation *openapi3.Operation) error {
+	hiddenNames, err := extensionStrings(operation.Extensions, hiddenParamsExtension)
+	if err != nil {
+		return err
+	}
+
+	hidden := make(map[string]bool)
+	for _, name := range hiddenNames {
+		hidden[name] = true
+	}
+	for _, paramRef := range operation.Parameters {
+		if paramRef != nil && paramRef.Value != nil && extensionBool(paramRef.Value.Extensions, hiddenExtension) {
+			hidden[paramRef.Value.Name] = true
+		}
+	}
+	if len(hidden) == 0 {
+		return nil
+	}
+
+	visible := []Arg{}
+	for _, arg := range tool.Args {
+		if hidden[arg.Name] {
+			if arg.Required && (arg.Schema == nil || arg.Schema.Default == nil) {
+				return fmt.Errorf("required %s parameter %q cannot be hidden without a schema default", arg.Source, arg.Name)
+			}
+			tool.HiddenArgs = append(tool.HiddenArgs, arg)
+			delete(hidden, arg.Name)
+			continue
+		}
+		visible = append(visible, arg)
+	}
+	tool.Args = visible
+
+	for _, name := range hiddenNames {
+		if hidden[name] {
+			return fmt.Errorf("%s references unknown parameter %q", hiddenParamsExtension, name)
+		}
+	}
+	return nil
+}
+
+// applyParameterExtensions applies the x-mcp extensions of a parameter to its argument
+func applyParameterExtensions(arg *Arg, param *openapi3.Parameter) {
+	if description := extensionString(param.Extensions, descriptionExtension); description != "" {
+		arg.Description = description
+		// The schema description takes precedence in the input schema
+		if arg.Schema != nil {
+			arg.Schema.Description = description
+		}
+	}
+	if examples := extensionList(param.Extensions, examplesExtension); examples != nil && arg.Schema != nil {
+		arg.Schema.Examples = examples
+	}
+}
+
+// applySchemaExtensions applies the x-mcp extensions of a schema to its converted form
+func applySchemaExtensions(result *Schema, schema *openapi3.Schema) {
+	if description := extensionString(schema.Extensions, descriptionExtension); description != "" {
+		result.Description = description
+	}
+	if examples := extensionList(schema.Extensions, examplesExtension); examples != nil {
+		result.Examples = examples
+	}
+}
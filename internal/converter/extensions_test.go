@@ -0,0 +1,204 @@
+package converter
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const extensionsSpec = `openapi: 3.0.3
+info:
+  title: Extensions API
+  version: "1.0.0"
+paths:
+  /accounts/{accountId}/todos:
+    post:
+      operationId: createTodo
+      summary: Create a todo (public docs)
+      x-mcp-name: add_todo
+      x-mcp-description: Add a todo to the user's list.
+      x-mcp-hidden-params: [accountId]
+      x-mcp-examples:
+        - body: {title: Buy milk}
+      parameters:
+        - name: accountId
+          in: path
+          required: true
+          schema:
+            type: string
+            default: me
+        - name: X-Trace
+          in: header
+          x-mcp-hidden: true
+          schema:
+            type: string
+        - name: priority
+          in: query
+          description: Public priority docs
+          x-mcp-description: Priority from 1 (low) to 5 (urgent).
+          x-mcp-examples: [1, 5]
+          schema:
+            type: integer
+      requestBody:
+        required: true
+        x-mcp-description: The todo to add.
+        content:
+          application/json:
+            schema:
+              $ref: "#/components/schemas/Todo"
+      responses:
+        "201":
+          description: Created
+components:
+  schemas:
+    Todo:
+      type: object
+      required: [title, ownerId]
+      properties:
+        title:
+          type: string
+          description: Public title docs
+          x-mcp-description: Short title of the todo.
+          x-mcp-examples: [Buy milk]
+        ownerId:
+          type: string
+          x-mcp-hidden: true
+`
+
+func convertExtensionsSpec(t *testing.T, spec string) *Tool {
+	t.Helper()
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(spec)); err != nil {
+		t.Fatalf("failed to parse OpenAPI: %v", err)
+	}
+	config, err := NewConverter(parser).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if len(config.Tools) != 1 {
+		t.Fatalf("expected 1 tool, got %d", len(config.Tools))
+	}
+	return &config.Tools[0]
+}
+
+func TestConverter_Extensions(t *testing.T) {
+	tool := convertExtensionsSpec(t, extensionsSpec)
+
+	if tool.Name != "createTodo" || tool.MCPName != "add_todo" {
+		t.Errorf("Name = %q, MCPName = %q, want createTodo and add_todo", tool.Name, tool.MCPName)
+	}
+	if tool.Description != "Add a todo to the user's list." {
+		t.Errorf("Description = %q, want the x-mcp-description", tool.Description)
+	}
+
+	var visible, hidden []string
+	for _, arg := range tool.Args {
+		visible = append(visible, arg.Name)
+	}
+	for _, arg := range tool.HiddenArgs {
+		hidden = append(hidden, arg.Name)
+	}
+	if !reflect.DeepEqual(visible, []string{"body", "priority"}) {
+		t.Errorf("Args = %v, want [body priority]", visible)
+	}
+	if !reflect.DeepEqual(hidden, []string{"X-Trace", "accountId"}) {
+		t.Errorf("HiddenArgs = %v, want [X-Trace accountId]", hidden)
+	}
+
+	var schema map[string]any
+	if err := json.Unmarshal([]byte(tool.RawInputSchema), &schema); err != nil {
+		t.Fatalf("invalid input schema: %v", err)
+	}
+	wantExamples := []any{map[string]any{"body": map[string]any{"title": "Buy milk"}}}
+	if !reflect.DeepEqual(schema["examples"], wantExamples) {
+		t.Errorf("examples = %v, want %v", schema["examples"], wantExamples)
+	}
+	if !reflect.DeepEqual(schema["required"], []any{"body"}) {
+		t.Errorf("required = %v, want only body", schema["required"])
+	}
+
+	properties := schema["properties"].(map[string]any)
+	priority := properties["priority"].(map[string]any)
+	if priority["description"] != "Priority from 1 (low) to 5 (urgent)." {
+		t.Errorf("priority description = %v, want the x-mcp-description", priority["description"])
+	}
+	if !reflect.DeepEqual(priority["examples"], []any{float64(1), float64(5)}) {
+		t.Errorf("priority examples = %v, want [1 5]", priority["examples"])
+	}
+
+	body := properties["body"].(map[string]any)
+	if body["description"] != "The todo to add." {
+		t.Errorf("body description = %v, want the request body x-mcp-description", body["description"])
+	}
+	if !reflect.DeepEqual(body["required"], []any{"title"}) {
+		t.Errorf("body required = %v, want hidden ownerId removed", body["required"])
+	}
+	bodyProperties := body["properties"].(map[string]any)
+	if _, ok := bodyProperties["ownerId"]; ok {
+		t.Error("expected hidden ownerId property to be removed")
+	}
+	title := bodyProperties["title"].(map[string]any)
+	if title["description"] != "Short title of the todo." || !reflect.DeepEqual(title["examples"], []any{"Buy milk"}) {
+		t.Errorf("title schema = %v, want the x-mcp-description and x-mcp-examples", title)
+	}
+}
+
+func TestConverter_Extensions_UnknownHiddenParam(t *testing.T) {
+	spec := strings.Replace(extensionsSpec, "x-mcp-hidden-params: [accountId]", "x-mcp-hidden-params: [acountId]", 1)
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(spec)); err != nil {
+		t.Fatalf("failed to parse OpenAPI: %v", err)
+	}
+	_, err := NewConverter(parser).Convert()
+	if err == nil || !strings.Contains(err.Error(), `unknown parameter "acountId"`) {
+		t.Errorf("Convert() error = %v, want unknown parameter error", err)
+	}
+}
+
+func TestConverter_Extensions_HiddenRequiredWithoutDefault(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+	}{
+		{
+			name: "x-mcp-hidden-params",
+			spec: strings.Replace(extensionsSpec, "            default: me\n", "", 1),
+		},
+		{
+			name: "x-mcp-hidden",
+			spec: strings.Replace(extensionsSpec, "        - name: X-Trace\n          in: header\n", "        - name: X-Trace\n          in: header\n          required: true\n", 1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := NewParser(false)
+			if err := parser.Parse([]byte(tt.spec)); err != nil {
+				t.Fatalf("failed to parse OpenAPI: %v", err)
+			}
+			_, err := NewConverter(parser).Convert()
+			if err == nil || !strings.Contains(err.Error(), "cannot be hidden without a schema default") {
+				t.Errorf("Convert() error = %v, want hidden required parameter error", err)
+			}
+		})
+	}
+}
+
+func TestExtensionList(t *testing.T) {
+	tests := []struct {
+		name       string
+		extensions map[string]any
+		want       []any
+	}{
+		{"missing", map[string]any{}, nil},
+		{"list", map[string]any{examplesExtension: []any{"a", "b"}}, []any{"a", "b"}},
+		{"single value", map[string]any{examplesExtension: "a"}, []any{"a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extensionList(tt.extensions, examplesExtension); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("extensionList() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/yaml"
+)
+
+// excludeExtension hides an operation from the generated tools
+const excludeExtension = "x-mcp-exclude"
+
+// regexPrefix marks a filter pattern as a regular expression instead of a glob
+const regexPrefix = "regex:"
+
+// ToolFilter selects the operations converted into tools.
+// Patterns are globs where * matches any sequence of characters and ? a single character,
+// or regular expressions when prefixed with "regex:". An operation is kept when it matches
+// at least one include pattern of every dimension that has some, and no exclude pattern.
+// Methods are matched case-insensitively.
+type ToolFilter struct {
+	IncludeTags         []string `json:"includeTags,omitempty"`
+	ExcludeTags         []string `json:"excludeTags,omitempty"`
+	IncludePaths        []string `json:"includePaths,omitempty"`
+	ExcludePaths        []string `json:"excludePaths,omitempty"`
+	IncludeMethods      []string `json:"includeMethods,omitempty"`
+	ExcludeMethods      []string `json:"excludeMethods,omitempty"`
+	IncludeOperationIDs []string `json:"includeOperationIds,omitempty"`
+	ExcludeOperationIDs []string `json:"excludeOperationIds,omitempty"`
+}
+
+// toolFilter is a ToolFilter with compiled patterns
+type toolFilter struct {
+	includeTags, excludeTags                 []*regexp.Regexp
+	includePaths, excludePaths               []*regexp.Regexp
+	includeMethods, excludeMethods           []*regexp.Regexp
+	includeOperationIDs, excludeOperationIDs []*regexp.Regexp
+}
+
+// compile compiles every pattern of the filter
+func (f *ToolFilter) compile() (*toolFilter, error) {
+	compiled := &toolFilter{}
+	groups := []struct {
+		name     string
+		patterns []string
+		target   *[]*regexp.Regexp
+		fold     bool
+	}{
+		{"includeTags", f.IncludeTags, &compiled.includeTags, false},
+		{"excludeTags", f.ExcludeTags, &compiled.excludeTags, false},
+		{"includePaths", f.IncludePaths, &compiled.includePaths, false},
+		{"excludePaths", f.ExcludePaths, &compiled.excludePaths, false},
+		{"includeMethods", f.IncludeMethods, &compiled.includeMethods, true},
+		{"excludeMethods", f.ExcludeMethods, &compiled.excludeMethods, true},
+		{"includeOperationIds", f.IncludeOperationIDs, &compiled.includeOperationIDs, false},
+		{"excludeOperationIds", f.ExcludeOperationIDs, &compiled.excludeOperationIDs, false},
+	}
+
+	for _, group := range groups {
+		for _, pattern := range group.patterns {
+			re, err := compilePattern(pattern, group.fold)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s pattern %q: %w", group.name, pattern, err)
+			}
+			*group.target = append(*group.target, re)
+		}
+	}
+	return compiled, nil
+}
+
+// compilePattern compiles a glob or "regex:" pattern into an anchored regular expression
+func compilePattern(pattern string, fold bool) (*regexp.Regexp, error) {
+	var expr string
+	if re, ok := strings.CutPrefix(pattern, regexPrefix); ok {
+		expr = re
+	} else {
+		var sb strings.Builder
+		sb.WriteString("^")
+		for _, r := range pattern {
+			switch r {
+			case '*':
+				sb.WriteString(".*")
+			case '?':
+				sb.WriteString(".")
+			default:
+				sb.WriteString(regexp.QuoteMeta(string(r)))
+			}
+		}
+		sb.WriteString("$")
+		expr = sb.String()
+	}
+
+	if fold {
+		expr = "(?i)" + expr
+	}
+	return regexp.Compile(expr)
+}
+
+// allows reports whether an operation is kept by the filter
+func (f *toolFilter) allows(path, method, operationID string, tags []string) bool {
+	values := []struct {
+		values           []string
+		include, exclude []*regexp.Regexp
+	}{
+		{tags, f.includeTags, f.excludeTags},
+		{[]string{path}, f.includePaths, f.excludePaths},
+		{[]string{method}, f.includeMethods, f.excludeMethods},
+		{[]string{operationID}, f.includeOperationIDs, f.excludeOperationIDs},
+	}
+
+	for _, v := range values {
+		if len(v.include) > 0 && !matchesAny(v.include, v.values) {
+			return false
+		}
+		if matchesAny(v.exclude, v.values) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesAny reports whether any of the values matches any of the patterns
+func matchesAny(patterns []*regexp.Regexp, values []string) bool {
+	for _, pattern := range patterns {
+		for _, value := range values {
+			if pattern.MatchString(value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isExcluded reports whether an operation opts out of tool generation through x-mcp-exclude
+func isExcluded(operation *openapi3.Operation) bool {
+	excluded, _ := operation.Extensions[excludeExtension].(bool)
+	return excluded
+}
+
+// SetToolFilter restricts the generated tools to the operations selected by the filter
+func (c *Converter) SetToolFilter(filter *ToolFilter) error {
+	if filter == nil {
+		c.filter = nil
+		return nil
+	}
+
+	compiled, err := filter.compile()
+	if err != nil {
+		return err
+	}
+	c.filter = compiled
+	return nil
+}
+
+// LoadToolFilter reads a tool filter from a YAML or JSON file
+func LoadToolFilter(filePath string) (*ToolFilter, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tool filter: %w", err)
+	}
+
+	var filter ToolFilter
+	// Unknown keys are rejected so that a misspelled filter does not silently select everything
+	if err := yaml.Unmarshal(data, &filter, disallowUnknownFields); err != nil {
+		return nil, fmt.Errorf("failed to parse tool filter %s: %w", filePath, err)
+	}
+	return &filter, nil
+}
+
+// disallowUnknownFields makes decoding fail on keys that do not match a field
+func disallowUnknownFields(decoder *json.Decoder) *json.Decoder {
+	decoder.DisallowUnknownFields()
+	return decoder
+}
@@ -0,0 +1,203 @@
+package converter
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const filterSpec = `openapi: 3.0.3
+info:
+  title: Filter API
+  version: "1.0.0"
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      tags: [pets, public]
+      responses:
+        "200":
+          description: OK
+    post:
+      operationId: createPet
+      tags: [pets]
+      responses:
+        "201":
+          description: Created
+  /pets/{petId}:
+    get:
+      operationId: getPet
+      tags: [pets, public]
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: OK
+    delete:
+      operationId: deletePet
+      tags: [pets]
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "204":
+          description: Deleted
+  /admin/users:
+    get:
+      operationId: listUsers
+      tags: [admin]
+      responses:
+        "200":
+          description: OK
+  /internal/health:
+    get:
+      operationId: health
+      x-mcp-exclude: true
+      responses:
+        "200":
+          description: OK
+`
+
+func convertFiltered(t *testing.T, filter *ToolFilter) []string {
+	t.Helper()
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(filterSpec)); err != nil {
+		t.Fatalf("failed to parse OpenAPI: %v", err)
+	}
+	c := NewConverter(parser)
+	if err := c.SetToolFilter(filter); err != nil {
+		t.Fatalf("SetToolFilter failed: %v", err)
+	}
+	config, err := c.Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	names := []string{}
+	for _, tool := range config.Tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+func TestConverter_ToolFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter *ToolFilter
+		want   []string
+	}{
+		{
+			name:   "no filter skips x-mcp-exclude",
+			filter: nil,
+			want:   []string{"createPet", "deletePet", "getPet", "listPets", "listUsers"},
+		},
+		{
+			name:   "include methods is case-insensitive",
+			filter: &ToolFilter{IncludeMethods: []string{"GET"}},
+			want:   []string{"getPet", "listPets", "listUsers"},
+		},
+		{
+			name:   "include tags",
+			filter: &ToolFilter{IncludeTags: []string{"public"}},
+			want:   []string{"getPet", "listPets"},
+		},
+		{
+			name:   "exclude tags",
+			filter: &ToolFilter{ExcludeTags: []string{"adm*"}},
+			want:   []string{"createPet", "deletePet", "getPet", "listPets"},
+		},
+		{
+			name:   "path glob matches across segments",
+			filter: &ToolFilter{IncludePaths: []string{"/pets*"}, ExcludePaths: []string{"/pets/{petId}"}},
+			want:   []string{"createPet", "listPets"},
+		},
+		{
+			name:   "operationId regex",
+			filter: &ToolFilter{IncludeOperationIDs: []string{"regex:^(list|get)"}},
+			want:   []string{"getPet", "listPets", "listUsers"},
+		},
+		{
+			name:   "exclude wins over include",
+			filter: &ToolFilter{IncludeTags: []string{"pets"}, ExcludeMethods: []string{"post", "delete"}},
+			want:   []string{"getPet", "listPets"},
+		},
+		{
+			name:   "include cannot bring back x-mcp-exclude",
+			filter: &ToolFilter{IncludeOperationIDs: []string{"health"}},
+			want:   []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := convertFiltered(t, tt.filter); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tools = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConverter_SetToolFilter_InvalidPattern(t *testing.T) {
+	c := NewConverter(NewParser(false))
+	if err := c.SetToolFilter(&ToolFilter{IncludePaths: []string{"regex:("}}); err == nil {
+		t.Fatal("expected an error for an invalid regular expression")
+	}
+}
+
+func TestCompilePattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		value   string
+		want    bool
+	}{
+		{"/pets", "/pets", true},
+		{"/pets", "/pets/1", false},
+		{"/pets/*", "/pets/{petId}/photos", true},
+		{"get?et", "getPet", true},
+		{"a.b", "axb", false},
+		{"regex:^/pets/\\{[a-z]+Id\\}$", "/pets/{petId}", true},
+		{"regex:pets", "/admin/pets/all", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			re, err := compilePattern(tt.pattern, false)
+			if err != nil {
+				t.Fatalf("compilePattern() error = %v", err)
+			}
+			if got := re.MatchString(tt.value); got != tt.want {
+				t.Errorf("match(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadToolFilter(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "filter.yaml")
+	if err := os.WriteFile(valid, []byte("includeMethods: [get]\nexcludePaths:\n  - /admin/*\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	filter, err := LoadToolFilter(valid)
+	if err != nil {
+		t.Fatalf("LoadToolFilter failed: %v", err)
+	}
+	want := &ToolFilter{IncludeMethods: []string{"get"}, ExcludePaths: []string{"/admin/*"}}
+	if !reflect.DeepEqual(filter, want) {
+		t.Errorf("LoadToolFilter() = %+v, want %+v", filter, want)
+	}
+
+	misspelled := filepath.Join(dir, "misspelled.yaml")
+	if err := os.WriteFile(misspelled, []byte("includeMethod: [get]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadToolFilter(misspelled); err == nil {
+		t.Error("expected an error for an unknown key")
+	}
+}
@@ -0,0 +1,64 @@
+package converter
+
+import "sort"
+
+// SetFlattenBody makes the properties of object request bodies tool arguments of their own instead of
+// the properties of a single body argument. Bodies with several content types or without a plain object
+// schema are kept whole. Properties named like a parameter are prefixed with body_, see resolveArgNames.
+func (c *Converter) SetFlattenBody(enabled bool) {
+	c.flattenBody = enabled
+}
+
+// flattenBodyArg returns one argument per property of the body object, or nil when the body is kept whole
+func (c *Converter) flattenBodyArg(body Arg) []Arg {
+	if !c.flattenBody || len(body.ContentTypes) != 1 {
+		return nil
+	}
+	var schema *Schema
+	for _, contentSchema := range body.ContentTypes {
+		schema = resolveDefinition(contentSchema, c.definitions)
+	}
+	if !isFlatObject(schema) {
+		return nil
+	}
+
+	required := make(map[string]bool, len(schema.Object.Required))
+	for _, name := range schema.Object.Required {
+		required[name] = true
+	}
+
+	properties := make([]string, 0, len(schema.Object.Properties))
+	for property := range schema.Object.Properties {
+		properties = append(properties, property)
+	}
+	sort.Strings(properties)
+
+	flattened := make([]Arg, 0, len(properties))
+	for _, property := range properties {
+		propertySchema := schema.Object.Properties[property]
+		flattened = append(flattened, Arg{
+			Name:         property,
+			Description:  propertySchema.Description,
+			Source:       "body",
+			BodyProperty: property,
+			Required:     body.Required && required[property],
+			Schema:       propertySchema,
+		})
+	}
+	return flattened
+}
+
+// isFlatObject reports whether a schema is an object whose properties describe all of its content,
+// which is required to rebuild it from its properties
+func isFlatObject(schema *Schema) bool {
+	if schema == nil || schema.Object == nil || len(schema.Object.Properties) == 0 {
+		return false
+	}
+	if len(schema.Types) != 1 || schema.Types[0] != "object" {
+		return false
+	}
+	if len(schema.OneOf) > 0 || len(schema.AnyOf) > 0 || len(schema.AllOf) > 0 || schema.Not != nil {
+		return false
+	}
+	return schema.Object.AdditionalProperties == nil
+}
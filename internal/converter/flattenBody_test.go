@@ -0,0 +1,192 @@
+package converter
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const flattenBodySpec = `openapi: 3.0.3
+info:
+  title: Flatten API
+  version: "1.0.0"
+paths:
+  /items/{id}:
+    put:
+      operationId: updateItem
+      x-mcp-examples:
+        - id: "7"
+          body:
+            id: "7"
+            name: Pen
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: "#/components/schemas/Item"
+      responses:
+        "204":
+          description: Updated
+  /notes:
+    post:
+      operationId: createNote
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [text]
+              properties:
+                text:
+                  type: string
+      responses:
+        "204":
+          description: Created
+  /tags:
+    post:
+      operationId: createTag
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                color:
+                  type: string
+      responses:
+        "204":
+          description: Created
+  /labels:
+    post:
+      operationId: createLabels
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+              additionalProperties:
+                type: string
+      responses:
+        "204":
+          description: Created
+  /uploads:
+    post:
+      operationId: upload
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: "#/components/schemas/Item"
+          application/x-www-form-urlencoded:
+            schema:
+              $ref: "#/components/schemas/Item"
+      responses:
+        "204":
+          description: Created
+components:
+  schemas:
+    Item:
+      type: object
+      required: [name]
+      properties:
+        id:
+          type: string
+        name:
+          type: string
+`
+
+// argSummary describes where an argument goes in the request
+type argSummary struct {
+	Source       string
+	BodyProperty string
+	Required     bool
+}
+
+func TestConverter_FlattenBody(t *testing.T) {
+	tests := []struct {
+		name    string
+		flatten bool
+		want    map[string]map[string]argSummary // Arguments by tool
+	}{
+		{
+			name: "disabled",
+			want: map[string]map[string]argSummary{
+				"updateItem": {"id": {"path", "", true}, "body": {"body", "", true}},
+				"createNote": {"body": {"body", "", false}},
+			},
+		},
+		{
+			name:    "enabled",
+			flatten: true,
+			want: map[string]map[string]argSummary{
+				// The id property collides with the path parameter
+				"updateItem": {"id": {"path", "", true}, "body_id": {"body", "id", false}, "name": {"body", "name", true}},
+				// Properties of an optional body are optional
+				"createNote": {"text": {"body", "text", false}},
+				// A required body sent without properties stays required by the request
+				"createTag": {"color": {"body", "color", false}},
+				// Additional properties and several content types cannot be flattened
+				"createLabels": {"body": {"body", "", false}},
+				"upload":       {"body": {"body", "", false}, "contentType": {"header", "", false}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := NewParser(false)
+			if err := parser.Parse([]byte(flattenBodySpec)); err != nil {
+				t.Fatalf("failed to parse OpenAPI: %v", err)
+			}
+			converter := NewConverter(parser)
+			converter.SetFlattenBody(tt.flatten)
+			config, err := converter.Convert()
+			if err != nil {
+				t.Fatalf("Convert failed: %v", err)
+			}
+
+			for _, tool := range config.Tools {
+				want, ok := tt.want[tool.Name]
+				if !ok {
+					continue
+				}
+				got := make(map[string]argSummary)
+				for _, arg := range tool.Args {
+					got[arg.Name] = argSummary{arg.Source, arg.BodyProperty, arg.Required}
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("%s args = %v, want %v", tool.Name, got, want)
+				}
+				if wantRequired := tool.Name == "updateItem" || tool.Name == "createTag"; tool.RequestTemplate.BodyRequired != wantRequired {
+					t.Errorf("%s body required = %v, want %v", tool.Name, tool.RequestTemplate.BodyRequired, wantRequired)
+				}
+
+				if tool.Name != "updateItem" || !tt.flatten {
+					continue
+				}
+				var schema map[string]any
+				if err := json.Unmarshal([]byte(tool.RawInputSchema), &schema); err != nil {
+					t.Fatalf("invalid input schema: %v", err)
+				}
+				wantExamples := []any{map[string]any{"id": "7", "body_id": "7", "name": "Pen"}}
+				if !reflect.DeepEqual(schema["examples"], wantExamples) {
+					t.Errorf("examples = %v, want %v", schema["examples"], wantExamples)
+				}
+				if required := schema["required"]; !reflect.DeepEqual(required, []any{"id", "name"}) {
+					t.Errorf("required = %v, want [id name]", required)
+				}
+			}
+		})
+	}
+}
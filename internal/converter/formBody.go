@@ -0,0 +1,112 @@
+package converter
+
+import (
+	"mime"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// fileDescription documents the values accepted by binary arguments
+const fileDescription = "File content: a base64 encoded string, or a resource with its uri and its content as a base64 blob or as text."
+
+// isFormContentType reports whether a request body sends its properties as form fields
+func isFormContentType(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return mediaType == "application/x-www-form-urlencoded" || mediaType == "multipart/form-data"
+}
+
+// bodyEncoding describes how the request body is sent with a content type: the binary properties and
+// the encoding objects of form bodies, or whether the whole body is a file
+func bodyEncoding(contentType string, mediaType *openapi3.MediaType) BodyEncoding {
+	var encoding BodyEncoding
+	if mediaType == nil || mediaType.Schema == nil || mediaType.Schema.Value == nil {
+		return encoding
+	}
+	schema := mediaType.Schema.Value
+	if !isFormContentType(contentType) {
+		encoding.Binary = isBinarySchema(schema)
+		return encoding
+	}
+
+	multipart, _, _ := mime.ParseMediaType(contentType)
+	for name, property := range schema.Properties {
+		var field FieldEncoding
+		if property != nil && property.Value != nil {
+			field.Binary = isBinarySchema(property.Value) ||
+				(property.Value.Items != nil && property.Value.Items.Value != nil && isBinarySchema(property.Value.Items.Value))
+		}
+		if fieldEncoding := mediaType.Encoding[name]; fieldEncoding != nil {
+			field.ContentType = fieldEncoding.ContentType
+			// Styles only apply to form-urlencoded bodies
+			if multipart != "multipart/form-data" && (fieldEncoding.Style != "" || fieldEncoding.Explode != nil) {
+				method := fieldEncoding.SerializationMethod()
+				field.Style, field.Explode = method.Style, method.Explode
+			}
+		}
+		if field == (FieldEncoding{}) {
+			continue
+		}
+		if encoding.Fields == nil {
+			encoding.Fields = make(map[string]FieldEncoding)
+		}
+		encoding.Fields[name] = field
+	}
+	return encoding
+}
+
+// isBinarySchema reports whether a schema describes file content
+func isBinarySchema(schema *openapi3.Schema) bool {
+	return schema.Format == "binary" && (schema.Type == nil || schema.Type.Is("string"))
+}
+
+// isFileSchema reports whether a converted schema describes file content
+func isFileSchema(s *Schema) bool {
+	if s.Format != "binary" {
+		return false
+	}
+	for _, t := range s.Types {
+		if t != "string" && t != "null" {
+			return false
+		}
+	}
+	return true
+}
+
+// fileSchemaToDraft7Map describes file content in the input schema: JSON cannot carry raw bytes, so files are
+// sent as base64 strings or as MCP resources with their content
+func fileSchemaToDraft7Map(s *Schema) map[string]interface{} {
+	result := make(map[string]interface{})
+	if s.Title != "" {
+		result["title"] = s.Title
+	}
+	result["description"] = fileDescription
+	if description := strings.TrimSpace(s.Description); description != "" {
+		if !strings.HasSuffix(description, ".") {
+			description += "."
+		}
+		result["description"] = description + " " + fileDescription
+	}
+
+	options := []interface{}{
+		map[string]interface{}{"type": "string", "contentEncoding": "base64"},
+		map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"uri":      map[string]interface{}{"type": "string"},
+				"name":     map[string]interface{}{"type": "string"},
+				"mimeType": map[string]interface{}{"type": "string"},
+				"blob":     map[string]interface{}{"type": "string", "contentEncoding": "base64"},
+				"text":     map[string]interface{}{"type": "string"},
+			},
+			"required": []interface{}{"uri"},
+		},
+	}
+	for _, t := range s.Types {
+		if t == "null" {
+			options = append(options, map[string]interface{}{"type": "null"})
+		}
+	}
+	result["anyOf"] = options
+	return result
+}
@@ -0,0 +1,153 @@
+package converter
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const formBodySpec = `openapi: 3.0.3
+info:
+  title: Upload API
+  version: "1.0.0"
+paths:
+  /documents:
+    post:
+      operationId: uploadDocument
+      requestBody:
+        content:
+          multipart/form-data:
+            schema:
+              type: object
+              properties:
+                file:
+                  type: string
+                  format: binary
+                  description: The document
+                attachments:
+                  type: array
+                  items:
+                    type: string
+                    format: binary
+                title:
+                  type: string
+            encoding:
+              file:
+                contentType: application/pdf
+                style: deepObject
+      responses:
+        "201":
+          description: Created
+  /forms:
+    post:
+      operationId: submitForm
+      requestBody:
+        content:
+          application/x-www-form-urlencoded:
+            schema:
+              type: object
+              properties:
+                filter:
+                  type: object
+                ids:
+                  type: array
+                  items:
+                    type: integer
+            encoding:
+              filter:
+                style: deepObject
+              ids:
+                explode: false
+      responses:
+        "204":
+          description: OK
+  /images:
+    put:
+      operationId: putImage
+      requestBody:
+        content:
+          image/png:
+            schema:
+              type: string
+              format: binary
+      responses:
+        "204":
+          description: OK
+`
+
+func TestConverter_FormBody(t *testing.T) {
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(formBodySpec)); err != nil {
+		t.Fatalf("failed to parse OpenAPI: %v", err)
+	}
+	config, err := NewConverter(parser).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	tests := []struct {
+		tool      string
+		form      bool
+		encodings map[string]BodyEncoding
+	}{
+		{
+			tool: "uploadDocument",
+			form: true,
+			// Styles are ignored in multipart bodies
+			encodings: map[string]BodyEncoding{"multipart/form-data": {Fields: map[string]FieldEncoding{
+				"file":        {ContentType: "application/pdf", Binary: true},
+				"attachments": {Binary: true},
+			}}},
+		},
+		{
+			tool: "submitForm",
+			form: true,
+			encodings: map[string]BodyEncoding{"application/x-www-form-urlencoded": {Fields: map[string]FieldEncoding{
+				"filter": {Style: "deepObject", Explode: true},
+				"ids":    {Style: "form", Explode: false},
+			}}},
+		},
+		{tool: "putImage", encodings: map[string]BodyEncoding{"image/png": {Binary: true}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			var tool *Tool
+			for i := range config.Tools {
+				if config.Tools[i].Name == tt.tool {
+					tool = &config.Tools[i]
+				}
+			}
+			if tool == nil {
+				t.Fatalf("tool %s not found", tt.tool)
+			}
+			template := tool.RequestTemplate
+			if template.ArgsToFormBody != tt.form {
+				t.Errorf("ArgsToFormBody = %v, want %v", template.ArgsToFormBody, tt.form)
+			}
+			if !reflect.DeepEqual(template.BodyEncodings, tt.encodings) {
+				t.Errorf("BodyEncodings = %v, want %v", template.BodyEncodings, tt.encodings)
+			}
+		})
+	}
+}
+
+func TestFileSchemaToDraft7Map(t *testing.T) {
+	schema, err := schemaToDraft7Map(&Schema{Types: []string{"string", "null"}, Format: "binary", Description: "The document"})
+	if err != nil {
+		t.Fatalf("schemaToDraft7Map failed: %v", err)
+	}
+	if _, ok := schema["type"]; ok {
+		t.Errorf("file schema has a type, want anyOf only: %v", schema)
+	}
+	if description := schema["description"].(string); !strings.HasPrefix(description, "The document. File content") {
+		t.Errorf("description = %q", description)
+	}
+
+	data, _ := json.Marshal(schema["anyOf"])
+	for _, want := range []string{`"contentEncoding":"base64"`, `"required":["uri"]`, `{"type":"null"}`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("anyOf %s does not contain %s", data, want)
+		}
+	}
+}
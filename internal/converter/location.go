@@ -0,0 +1,138 @@
+package converter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// parseLocation turns a document location into a URL.
+// http(s):// and file:// URLs are supported, anything else is a local file path.
+func parseLocation(location string) (*url.URL, error) {
+	if !strings.HasPrefix(location, "http://") && !strings.HasPrefix(location, "https://") && !strings.HasPrefix(location, "file://") {
+		return &url.URL{Path: filepath.ToSlash(location)}, nil
+	}
+
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid document location %q: %w", location, err)
+	}
+	if u.Scheme == "file" {
+		if u.Host != "" && u.Host != "localhost" {
+			return nil, fmt.Errorf("unsupported file URL %q: only local files can be read", location)
+		}
+		// Plain paths let the loader resolve relative references against the file
+		return &url.URL{Path: u.Path}, nil
+	}
+	return u, nil
+}
+
+// readFromURI reads the document at a location, it is used by the loader for every external reference.
+// Local files are read directly, remote documents go through the cache directory when one is set.
+// The documents referenced by an OpenAPI 3.1 document are normalized like it.
+func (p *Parser) readFromURI(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+	data, err := p.readDocument(loader, location)
+	if err != nil || loader == nil || !p.openapi31 {
+		return data, err
+	}
+	normalized, err := normalizeReferencedOpenAPI31(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize %s: %w", location, err)
+	}
+	return normalized, nil
+}
+
+// readDocument reads the document at a location as it is
+func (p *Parser) readDocument(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+	if location.Scheme != "http" && location.Scheme != "https" {
+		return openapi3.ReadFromFile(loader, location)
+	}
+
+	remote := *location
+	remote.Fragment = ""
+
+	cachePath := ""
+	if p.CacheDir != "" {
+		cachePath = filepath.Join(p.CacheDir, cacheFileName(&remote))
+		if data, err := os.ReadFile(cachePath); err == nil {
+			return data, nil
+		}
+	}
+
+	data, err := p.fetch(&remote)
+	if err != nil {
+		return nil, err
+	}
+
+	if cachePath != "" {
+		if err := writeCacheFile(cachePath, data); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// fetch downloads a remote document
+func (p *Parser) fetch(location *url.URL) ([]byte, error) {
+	client := p.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Get(location.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", location, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("failed to fetch %s: server returned %s", location, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", location, err)
+	}
+	return data, nil
+}
+
+// cacheFileName derives a stable file name for a remote document from its URL
+func cacheFileName(location *url.URL) string {
+	sum := sha256.Sum256([]byte(location.String()))
+	return hex.EncodeToString(sum[:]) + path.Ext(location.Path)
+}
+
+// writeCacheFile stores a remote document in the cache, replacing the file atomically
+func writeCacheFile(cachePath string, data []byte) error {
+	dir := filepath.Dir(cachePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".download-*")
+	if err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), cachePath); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package converter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const remoteMainSpec = `openapi: 3.0.3
+info:
+  title: Remote API
+  version: "1.0.0"
+servers:
+  - url: https://api.example.com
+paths:
+  /pets/{petId}:
+    get:
+      operationId: getPet
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: The pet
+          content:
+            application/json:
+              schema:
+                $ref: "schemas/pet.yaml#/Pet"
+`
+
+const remotePetSchema = `Pet:
+  type: object
+  properties:
+    name:
+      type: string
+`
+
+// newSpecServer serves a spec split across two files and counts the requests it receives
+func newSpecServer(t *testing.T) (*httptest.Server, *int) {
+	t.Helper()
+	requests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/specs/api.yaml", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(remoteMainSpec))
+	})
+	mux.HandleFunc("/specs/schemas/pet.yaml", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(remotePetSchema))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func assertRemotePetResolved(t *testing.T, p *Parser) {
+	t.Helper()
+	pathItem := p.GetPaths()["/pets/{petId}"]
+	if pathItem == nil || pathItem.Get == nil {
+		t.Fatal("expected GET /pets/{petId}")
+	}
+	schema := pathItem.Get.Responses.Status(200).Value.Content["application/json"].Schema
+	if schema.Value == nil || schema.Value.Properties["name"] == nil {
+		t.Errorf("expected remote $ref to be resolved, got %+v", schema)
+	}
+}
+
+func TestParseLocation(t *testing.T) {
+	tests := []struct {
+		name     string
+		location string
+		want     string
+		wantErr  bool
+	}{
+		{"relative path", "specs/api.yaml", "specs/api.yaml", false},
+		{"absolute path", "/specs/api.yaml", "/specs/api.yaml", false},
+		{"file URL", "file:///specs/api.yaml", "/specs/api.yaml", false},
+		{"file URL with localhost", "file://localhost/specs/api.yaml", "/specs/api.yaml", false},
+		{"file URL with remote host", "file://server/specs/api.yaml", "", true},
+		{"http URL", "http://example.com/api.yaml", "http://example.com/api.yaml", false},
+		{"https URL", "https://example.com/api.yaml?v=2", "https://example.com/api.yaml?v=2", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseLocation(tt.location)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseLocation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("parseLocation() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParser_ParseLocation_RemoteRefs(t *testing.T) {
+	server, _ := newSpecServer(t)
+
+	p := NewParser(true)
+	if err := p.ParseLocation(server.URL + "/specs/api.yaml"); err != nil {
+		t.Fatalf("ParseLocation failed: %v", err)
+	}
+	assertRemotePetResolved(t, p)
+}
+
+func TestParser_ParseLocation_Cache(t *testing.T) {
+	server, requests := newSpecServer(t)
+	cacheDir := t.TempDir()
+	location := server.URL + "/specs/api.yaml"
+
+	p := NewParser(false)
+	p.CacheDir = cacheDir
+	if err := p.ParseLocation(location); err != nil {
+		t.Fatalf("ParseLocation failed: %v", err)
+	}
+	if *requests != 2 {
+		t.Errorf("expected 2 requests, got %d", *requests)
+	}
+
+	entries, err := os.ReadDir(cacheDir)
+	if err != nil {
+		t.Fatalf("failed to read cache directory: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("expected 2 cached documents, got %d", len(entries))
+	}
+
+	// The second run must not need the network
+	server.Close()
+	p = NewParser(false)
+	p.CacheDir = cacheDir
+	if err := p.ParseLocation(location); err != nil {
+		t.Fatalf("ParseLocation from cache failed: %v", err)
+	}
+	assertRemotePetResolved(t, p)
+}
+
+func TestParser_ParseLocation_HTTPError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	p := NewParser(false)
+	p.CacheDir = t.TempDir()
+	if err := p.ParseLocation(server.URL + "/missing.yaml"); err == nil {
+		t.Fatal("expected an error for a missing remote document")
+	}
+	if entries, _ := os.ReadDir(p.CacheDir); len(entries) != 0 {
+		t.Errorf("expected failed downloads not to be cached, got %d entries", len(entries))
+	}
+}
+
+func TestParser_ParseLocation_LocalRefs(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "schemas"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "api.yaml"), []byte(remoteMainSpec), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "schemas", "pet.yaml"), []byte(remotePetSchema), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, location := range []string{filepath.Join(dir, "api.yaml"), "file://" + filepath.ToSlash(filepath.Join(dir, "api.yaml"))} {
+		p := NewParser(false)
+		if err := p.ParseLocation(location); err != nil {
+			t.Fatalf("ParseLocation(%s) failed: %v", location, err)
+		}
+		assertRemotePetResolved(t, p)
+	}
+}
+
+func TestParser_ParseLocation_OpenAPI31Refs(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api.yaml", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Replace(remoteMainSpec, "openapi: 3.0.3", "openapi: 3.1.0", 1)))
+	})
+	mux.HandleFunc("/schemas/pet.yaml", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("Pet:\n  type: object\n  properties:\n    name:\n      type: [string, \"null\"]\n"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	p := NewParser(false)
+	if err := p.ParseLocation(server.URL + "/api.yaml"); err != nil {
+		t.Fatalf("ParseLocation failed: %v", err)
+	}
+	schema := p.GetPaths()["/pets/{petId}"].Get.Responses.Status(200).Value.Content["application/json"].Schema.Value
+	name := schema.Properties["name"].Value
+	if !name.Type.Is("string") || !name.Nullable {
+		t.Errorf("expected the remote 3.1 schema to be normalized, got type %v nullable %v", name.Type, name.Nullable)
+	}
+}
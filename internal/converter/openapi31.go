@@ -0,0 +1,400 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/oasdiff/yaml"
+)
+
+// normalizeOpenAPI31 rewrites an OpenAPI 3.1 document into the 3.0 form understood by the loader.
+// JSON Schema 2020-12 keywords are mapped onto their 3.0 equivalents so that no information used
+// by the converter is lost. Documents of other versions are returned unchanged with false.
+func normalizeOpenAPI31(data []byte) ([]byte, bool, error) {
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read OpenAPI document: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.UseNumber()
+	var doc map[string]any
+	if err := decoder.Decode(&doc); err != nil {
+		// Not an object, let the loader report the error
+		return data, false, nil
+	}
+
+	version, _ := doc["openapi"].(string)
+	if !strings.HasPrefix(version, "3.1") {
+		return data, false, nil
+	}
+
+	n := &openAPI31Normalizer{doc: doc, refs: make(map[string]string)}
+	n.normalize()
+
+	normalized, err := json.Marshal(n.doc)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to write normalized OpenAPI 3.1 document: %w", err)
+	}
+	return normalized, true, nil
+}
+
+// normalizeReferencedOpenAPI31 rewrites a document referenced by an OpenAPI 3.1 document into the 3.0 form.
+// Complete documents are normalized as such. Other documents, such as files of schemas, cannot tell their
+// schemas apart from other objects: every object is normalized as a schema, which leaves the others unchanged.
+func normalizeReferencedOpenAPI31(data []byte) ([]byte, error) {
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read referenced document: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.UseNumber()
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		// Let the loader report the error
+		return data, nil
+	}
+	root, ok := doc.(map[string]any)
+	if !ok {
+		return data, nil
+	}
+	if _, ok := root["openapi"]; ok {
+		normalized, _, err := normalizeOpenAPI31(data)
+		return normalized, err
+	}
+
+	n := &openAPI31Normalizer{doc: root, refs: make(map[string]string)}
+	n.walkSchemas(root, "#", false)
+	if len(n.refs) > 0 {
+		n.rewriteRefs(n.doc)
+	}
+
+	normalized, err := json.Marshal(n.doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write normalized referenced document: %w", err)
+	}
+	return normalized, nil
+}
+
+// openAPI31Normalizer holds the state of an OpenAPI 3.1 normalization
+type openAPI31Normalizer struct {
+	doc map[string]any
+	// refs maps the JSON pointer of each hoisted $defs entry to its new components reference
+	refs map[string]string
+}
+
+// normalize applies every 3.1 to 3.0 rewrite to the document
+func (n *openAPI31Normalizer) normalize() {
+	// Webhooks describe calls made by the API, not operations that can become tools
+	delete(n.doc, "webhooks")
+
+	if _, ok := n.doc["paths"]; !ok {
+		n.doc["paths"] = map[string]any{}
+	}
+
+	n.inlinePathItems()
+
+	components, _ := n.doc["components"].(map[string]any)
+	if schemas, ok := components["schemas"].(map[string]any); ok {
+		for _, name := range sortedMapKeys(schemas) {
+			n.normalizeSchema(schemas[name], "#/components/schemas/"+escapePointer(name))
+		}
+	}
+
+	for _, key := range sortedMapKeys(n.doc) {
+		if key == "components" {
+			for _, section := range sortedMapKeys(components) {
+				if section != "schemas" {
+					n.walk(components[section], "#/components/"+escapePointer(section))
+				}
+			}
+			continue
+		}
+		n.walk(n.doc[key], "#/"+escapePointer(key))
+	}
+
+	if len(n.refs) > 0 {
+		n.rewriteRefs(n.doc)
+	}
+}
+
+// inlinePathItems replaces references to components.pathItems, which 3.0 does not support
+func (n *openAPI31Normalizer) inlinePathItems() {
+	components, _ := n.doc["components"].(map[string]any)
+	pathItems, _ := components["pathItems"].(map[string]any)
+	if pathItems == nil {
+		return
+	}
+
+	paths, _ := n.doc["paths"].(map[string]any)
+	for path, item := range paths {
+		itemMap, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		ref, _ := itemMap["$ref"].(string)
+		name, found := strings.CutPrefix(ref, "#/components/pathItems/")
+		if !found {
+			continue
+		}
+		if target, ok := pathItems[unescapePointer(name)]; ok {
+			paths[path] = target
+		}
+	}
+	delete(components, "pathItems")
+}
+
+// walk visits a non-schema part of the document looking for embedded schemas
+func (n *openAPI31Normalizer) walk(node any, pointer string) {
+	switch v := node.(type) {
+	case map[string]any:
+		for _, key := range sortedMapKeys(v) {
+			switch key {
+			case "schema":
+				n.normalizeSchema(v[key], pointer+"/schema")
+			case "example", "examples", "default", "enum", "const":
+				// Literal values, never schemas
+			default:
+				n.walk(v[key], pointer+"/"+escapePointer(key))
+			}
+		}
+	case []any:
+		for i, item := range v {
+			n.walk(item, fmt.Sprintf("%s/%d", pointer, i))
+		}
+	}
+}
+
+// walkSchemas normalizes every object of a referenced document as a schema, except literal values.
+// The keys of maps of named schemas, such as properties, are names rather than keywords.
+func (n *openAPI31Normalizer) walkSchemas(node any, pointer string, named bool) {
+	switch v := node.(type) {
+	case map[string]any:
+		if !named {
+			n.normalizeSchema(v, pointer)
+		}
+		for _, key := range sortedMapKeys(v) {
+			switch {
+			case named:
+				n.walkSchemas(v[key], pointer+"/"+escapePointer(key), false)
+			case key == "example", key == "examples", key == "default", key == "enum", key == "const":
+			default:
+				n.walkSchemas(v[key], pointer+"/"+escapePointer(key), namedSchemasKeys[key])
+			}
+		}
+	case []any:
+		for i, item := range v {
+			n.walkSchemas(item, fmt.Sprintf("%s/%d", pointer, i), false)
+		}
+	}
+}
+
+// namedSchemasKeys are the keywords holding maps of schemas by name
+var namedSchemasKeys = map[string]bool{"properties": true, "patternProperties": true, "dependentSchemas": true, "$defs": true, "schemas": true}
+
+// normalizeSchema rewrites a JSON Schema 2020-12 schema and its sub-schemas in place
+func (n *openAPI31Normalizer) normalizeSchema(node any, pointer string) {
+	schema, ok := node.(map[string]any)
+	if !ok {
+		return
+	}
+
+	normalizeSchemaType(schema)
+
+	// const becomes a single value enum
+	if value, ok := schema["const"]; ok {
+		if _, hasEnum := schema["enum"]; !hasEnum {
+			schema["enum"] = []any{value}
+		}
+		delete(schema, "const")
+	}
+
+	// examples arrays become a single example
+	if examples, ok := schema["examples"].([]any); ok {
+		if _, hasExample := schema["example"]; !hasExample && len(examples) > 0 {
+			schema["example"] = examples[0]
+		}
+		delete(schema, "examples")
+	}
+
+	normalizeExclusiveBound(schema, "exclusiveMinimum", "minimum")
+	normalizeExclusiveBound(schema, "exclusiveMaximum", "maximum")
+
+	// Boolean schemas are not supported by 3.0 where a schema object is expected
+	for _, key := range []string{"items", "not"} {
+		if _, isBool := schema[key].(bool); isBool {
+			delete(schema, key)
+		}
+	}
+
+	// Sub-schemas
+	for _, key := range []string{"items", "not", "additionalProperties", "if", "then", "else", "contains", "propertyNames", "unevaluatedItems", "unevaluatedProperties"} {
+		n.normalizeSchema(schema[key], pointer+"/"+key)
+	}
+	for _, key := range []string{"allOf", "anyOf", "oneOf", "prefixItems"} {
+		if list, ok := schema[key].([]any); ok {
+			for i, sub := range list {
+				n.normalizeSchema(sub, fmt.Sprintf("%s/%s/%d", pointer, key, i))
+			}
+		}
+	}
+	for _, key := range []string{"properties", "patternProperties", "dependentSchemas"} {
+		if properties, ok := schema[key].(map[string]any); ok {
+			for _, name := range sortedMapKeys(properties) {
+				n.normalizeSchema(properties[name], pointer+"/"+key+"/"+escapePointer(name))
+			}
+		}
+	}
+
+	if defs, ok := schema["$defs"].(map[string]any); ok {
+		for _, name := range sortedMapKeys(defs) {
+			defPointer := pointer + "/$defs/" + escapePointer(name)
+			n.normalizeSchema(defs[name], defPointer)
+			n.hoistDefinition(name, defs[name], defPointer)
+		}
+		delete(schema, "$defs")
+	}
+}
+
+// hoistDefinition moves a $defs entry to components.schemas and records its new reference
+func (n *openAPI31Normalizer) hoistDefinition(name string, def any, pointer string) {
+	components, ok := n.doc["components"].(map[string]any)
+	if !ok {
+		components = map[string]any{}
+		n.doc["components"] = components
+	}
+	schemas, ok := components["schemas"].(map[string]any)
+	if !ok {
+		schemas = map[string]any{}
+		components["schemas"] = schemas
+	}
+
+	unique := name
+	for i := 2; schemas[unique] != nil; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	schemas[unique] = def
+	n.refs[pointer] = "#/components/schemas/" + escapePointer(unique)
+}
+
+// rewriteRefs points references to hoisted $defs entries at their new location
+func (n *openAPI31Normalizer) rewriteRefs(node any) {
+	switch v := node.(type) {
+	case map[string]any:
+		if ref, ok := v["$ref"].(string); ok {
+			v["$ref"] = n.resolveRef(ref)
+		}
+		for _, value := range v {
+			n.rewriteRefs(value)
+		}
+	case []any:
+		for _, item := range v {
+			n.rewriteRefs(item)
+		}
+	}
+}
+
+// resolveRef returns the new reference for a pointer into a hoisted definition, longest match first
+func (n *openAPI31Normalizer) resolveRef(ref string) string {
+	best := ""
+	for pointer := range n.refs {
+		if (ref == pointer || strings.HasPrefix(ref, pointer+"/")) && len(pointer) > len(best) {
+			best = pointer
+		}
+	}
+	if best == "" {
+		return ref
+	}
+	// A pointer into a definition nested in another one may need several rewrites
+	return n.resolveRef(n.refs[best] + strings.TrimPrefix(ref, best))
+}
+
+// normalizeSchemaType turns 3.1 type arrays containing "null" into a type and nullable
+func normalizeSchemaType(schema map[string]any) {
+	var types []string
+	switch t := schema["type"].(type) {
+	case string:
+		types = []string{t}
+	case []any:
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				types = append(types, s)
+			}
+		}
+	default:
+		return
+	}
+
+	var nonNull []any
+	nullable := false
+	for _, t := range types {
+		if t == "null" {
+			nullable = true
+			continue
+		}
+		nonNull = append(nonNull, t)
+	}
+
+	switch len(nonNull) {
+	case 0:
+		delete(schema, "type")
+	case 1:
+		schema["type"] = nonNull[0]
+	default:
+		schema["type"] = nonNull
+	}
+	if nullable {
+		schema["nullable"] = true
+	}
+}
+
+// normalizeExclusiveBound turns a numeric 3.1 exclusive bound into a 3.0 bound with a boolean flag
+func normalizeExclusiveBound(schema map[string]any, exclusiveKey, boundKey string) {
+	bound, ok := schema[exclusiveKey].(json.Number)
+	if !ok {
+		return
+	}
+
+	// Keep an inclusive bound that is already stricter than the exclusive one
+	if inclusive, ok := schema[boundKey].(json.Number); ok {
+		exclusiveValue, errExclusive := bound.Float64()
+		inclusiveValue, errInclusive := inclusive.Float64()
+		if errExclusive == nil && errInclusive == nil {
+			stricter := inclusiveValue > exclusiveValue
+			if boundKey == "maximum" {
+				stricter = inclusiveValue < exclusiveValue
+			}
+			if stricter {
+				delete(schema, exclusiveKey)
+				return
+			}
+		}
+	}
+
+	schema[boundKey] = bound
+	schema[exclusiveKey] = true
+}
+
+// sortedMapKeys returns the keys of a map in a stable order
+func sortedMapKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// escapePointer escapes a JSON pointer token
+func escapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// unescapePointer reverses escapePointer
+func unescapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
@@ -0,0 +1,250 @@
+package converter
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var openAPI31SpecPath = filepath.Join("..", "..", "testdata", "openapi31.yaml")
+
+func TestNormalizeOpenAPI31_NotOpenAPI31(t *testing.T) {
+	data := []byte("openapi: 3.0.3\ninfo:\n  title: T\n  version: '1'\npaths: {}\n")
+	got, is31, err := normalizeOpenAPI31(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if is31 {
+		t.Error("expected 3.0 document not to be reported as 3.1")
+	}
+	if string(got) != string(data) {
+		t.Errorf("expected 3.0 document to be returned unchanged, got %s", got)
+	}
+}
+
+func TestNormalizeOpenAPI31_Schemas(t *testing.T) {
+	data := []byte(`{
+  "openapi": "3.1.0",
+  "info": {"title": "T", "version": "1"},
+  "components": {
+    "schemas": {
+      "Value": {
+        "type": ["number", "null"],
+        "exclusiveMinimum": 1,
+        "minimum": 5,
+        "exclusiveMaximum": 10,
+        "examples": [6, 7]
+      },
+      "Kind": {"const": "a"},
+      "Multi": {"type": ["string", "integer"]},
+      "List": {"type": "array", "items": false},
+      "Outer": {
+        "$defs": {
+          "Inner": {
+            "$defs": {"Leaf": {"type": "string"}},
+            "properties": {"leaf": {"$ref": "#/components/schemas/Outer/$defs/Inner/$defs/Leaf"}}
+          }
+        },
+        "properties": {"inner": {"$ref": "#/components/schemas/Outer/$defs/Inner"}}
+      }
+    }
+  }
+}`)
+	got, is31, err := normalizeOpenAPI31(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !is31 {
+		t.Fatal("expected document to be reported as 3.1")
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(got, &doc); err != nil {
+		t.Fatalf("normalized document is not valid JSON: %v", err)
+	}
+	schemas := doc["components"].(map[string]any)["schemas"].(map[string]any)
+	if _, ok := doc["paths"]; !ok {
+		t.Error("expected empty paths to be added")
+	}
+
+	value := schemas["Value"].(map[string]any)
+	wantValue := map[string]any{
+		"type":             "number",
+		"nullable":         true,
+		"minimum":          float64(5),
+		"maximum":          float64(10),
+		"exclusiveMaximum": true,
+		"example":          float64(6),
+	}
+	if !reflect.DeepEqual(value, wantValue) {
+		t.Errorf("Value schema = %v, want %v", value, wantValue)
+	}
+
+	if kind := schemas["Kind"].(map[string]any); !reflect.DeepEqual(kind, map[string]any{"enum": []any{"a"}}) {
+		t.Errorf("Kind schema = %v, want const mapped to enum", kind)
+	}
+	if multi := schemas["Multi"].(map[string]any); !reflect.DeepEqual(multi["type"], []any{"string", "integer"}) {
+		t.Errorf("Multi schema type = %v, want both types kept", multi["type"])
+	}
+	if _, ok := schemas["List"].(map[string]any)["items"]; ok {
+		t.Error("expected boolean items to be removed")
+	}
+
+	outer := schemas["Outer"].(map[string]any)
+	if _, ok := outer["$defs"]; ok {
+		t.Error("expected $defs to be hoisted out of Outer")
+	}
+	innerRef := outer["properties"].(map[string]any)["inner"].(map[string]any)["$ref"]
+	if innerRef != "#/components/schemas/Inner" {
+		t.Errorf("inner $ref = %v, want #/components/schemas/Inner", innerRef)
+	}
+	leafRef := schemas["Inner"].(map[string]any)["properties"].(map[string]any)["leaf"].(map[string]any)["$ref"]
+	if leafRef != "#/components/schemas/Leaf" {
+		t.Errorf("leaf $ref = %v, want #/components/schemas/Leaf", leafRef)
+	}
+}
+
+func TestParser_ParseFile_OpenAPI31(t *testing.T) {
+	p := NewParser(false)
+	if err := p.ParseFile(openAPI31SpecPath); err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	paths := p.GetPaths()
+	if _, ok := paths["/pets/{petId}"]; !ok {
+		t.Fatalf("expected path item reference to be inlined, got paths %v", paths)
+	}
+
+	config, err := NewConverter(p).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if len(config.Tools) != 2 {
+		t.Fatalf("expected 2 tools (webhooks ignored), got %d", len(config.Tools))
+	}
+
+	var getPet *Tool
+	for i := range config.Tools {
+		if config.Tools[i].Name == "getPet" {
+			getPet = &config.Tools[i]
+		}
+	}
+	if getPet == nil {
+		t.Fatal("expected getPet tool")
+	}
+
+	var schema map[string]any
+	if err := json.Unmarshal([]byte(getPet.RawInputSchema), &schema); err != nil {
+		t.Fatalf("invalid input schema: %v", err)
+	}
+	properties := schema["properties"].(map[string]any)
+	petID := properties["petId"].(map[string]any)
+	if petID["exclusiveMinimum"] != float64(0) || petID["example"] != float64(42) {
+		t.Errorf("petId schema = %v, want exclusiveMinimum 0 and example 42", petID)
+	}
+	nickname := properties["nickname"].(map[string]any)
+	if !reflect.DeepEqual(nickname["type"], []any{"string", "null"}) {
+		t.Errorf("nickname type = %v, want [string null]", nickname["type"])
+	}
+
+	if !strings.Contains(getPet.Responses[0].PrependBody, "**label**") {
+		t.Errorf("expected $defs reference to be resolved in the response template, got:\n%s", getPet.Responses[0].PrependBody)
+	}
+}
+
+func TestParser_Parse_OpenAPI31(t *testing.T) {
+	p := NewParser(false)
+	data := []byte("openapi: 3.1.0\ninfo:\n  title: T\n  version: '1'\ncomponents:\n  schemas:\n    Id:\n      type: [integer, 'null']\n      exclusiveMinimum: 0\n")
+	if err := p.Parse(data); err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	schema := p.GetDocument().Components.Schemas["Id"].Value
+	if !schema.Nullable || !schema.ExclusiveMin || schema.Min == nil || *schema.Min != 0 {
+		t.Errorf("unexpected normalized schema: %+v", schema)
+	}
+}
+
+func TestNormalizeOpenAPI31_SubSchemaKeywords(t *testing.T) {
+	data := []byte(`{
+  "openapi": "3.1.0",
+  "info": {"title": "T", "version": "1"},
+  "components": {
+    "schemas": {
+      "Root": {
+        "prefixItems": [{"type": ["string", "null"]}],
+        "patternProperties": {"^x-": {"const": "a"}},
+        "dependentSchemas": {"a": {"examples": [1]}},
+        "if": {"type": ["integer", "null"]},
+        "then": {"exclusiveMinimum": 1},
+        "else": {"const": 2},
+        "contains": {"type": ["number", "null"]}
+      }
+    }
+  }
+}`)
+	got, _, err := normalizeOpenAPI31(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(got, &doc); err != nil {
+		t.Fatalf("normalized document is not valid JSON: %v", err)
+	}
+	root := doc["components"].(map[string]any)["schemas"].(map[string]any)["Root"].(map[string]any)
+
+	tests := []struct {
+		name string
+		got  any
+		want map[string]any
+	}{
+		{"prefixItems", root["prefixItems"].([]any)[0], map[string]any{"type": "string", "nullable": true}},
+		{"patternProperties", root["patternProperties"].(map[string]any)["^x-"], map[string]any{"enum": []any{"a"}}},
+		{"dependentSchemas", root["dependentSchemas"].(map[string]any)["a"], map[string]any{"example": float64(1)}},
+		{"if", root["if"], map[string]any{"type": "integer", "nullable": true}},
+		{"then", root["then"], map[string]any{"minimum": float64(1), "exclusiveMinimum": true}},
+		{"else", root["else"], map[string]any{"enum": []any{float64(2)}}},
+		{"contains", root["contains"], map[string]any{"type": "number", "nullable": true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeReferencedOpenAPI31(t *testing.T) {
+	data := []byte(`Pet:
+  type: object
+  properties:
+    const:
+      type: [string, "null"]
+    tag:
+      const: dog
+  $defs:
+    Id:
+      type: [integer, "null"]
+`)
+	got, err := normalizeReferencedOpenAPI31(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(got, &doc); err != nil {
+		t.Fatalf("normalized document is not valid JSON: %v", err)
+	}
+	properties := doc["Pet"].(map[string]any)["properties"].(map[string]any)
+	// A property named like a keyword is still a schema
+	if want := map[string]any{"type": "string", "nullable": true}; !reflect.DeepEqual(properties["const"], want) {
+		t.Errorf("const property = %v, want %v", properties["const"], want)
+	}
+	if want := map[string]any{"enum": []any{"dog"}}; !reflect.DeepEqual(properties["tag"], want) {
+		t.Errorf("tag property = %v, want %v", properties["tag"], want)
+	}
+	if id := doc["components"].(map[string]any)["schemas"].(map[string]any)["Id"]; !reflect.DeepEqual(id, map[string]any{"type": "integer", "nullable": true}) {
+		t.Errorf("hoisted Id = %v, want a nullable integer", id)
+	}
+}
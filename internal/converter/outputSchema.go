@@ -0,0 +1,105 @@
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// OutputProperty holds the response body in the structured content of tools whose success response is not an object,
+// MCP requires output schemas to describe an object
+const OutputProperty = "result"
+
+// createOutput converts the schema of the success response into the output schema of the tool.
+// Only operations whose single success response has a JSON body have an output schema, since the structured
+// content of every success response has to conform to it: a 204 or a text response next to it could not.
+func (c *Converter) createOutput(operation *openapi3.Operation) (*ToolOutput, error) {
+	if operation == nil || operation.Responses == nil {
+		return nil, nil
+	}
+
+	var (
+		code        string
+		statusCode  int
+		contentType string
+		response    *openapi3.Response
+	)
+	for _, responseCode := range sortedResponseCodes(operation.Responses) {
+		// Ranges such as 2XX are success responses too
+		if !strings.HasPrefix(responseCode, "2") {
+			continue
+		}
+		if code != "" {
+			return nil, nil
+		}
+		code = responseCode
+		status, err := strconv.Atoi(responseCode)
+		responseRef := operation.Responses.Map()[responseCode]
+		if err != nil || responseRef == nil || responseRef.Value == nil {
+			continue
+		}
+		statusCode, contentType, response = status, jsonContentType(responseRef.Value.Content), responseRef.Value
+	}
+	if contentType == "" {
+		return nil, nil
+	}
+
+	schema, err := c.applySchemaRef(response.Content[contentType].Schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert the %s response schema: %w", code, err)
+	}
+	// The output schema root describes the body itself rather than referencing its definition
+	schema = resolveDefinition(schema, c.definitions)
+	definitions := c.referencedDefinitions(schema)
+	schemaMap, err := schemaToDraft7Map(schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert the %s response schema: %w", code, err)
+	}
+
+	output := &ToolOutput{StatusCode: statusCode, ContentType: contentType}
+	if len(schema.Types) != 1 || schema.Types[0] != "object" {
+		output.Property = OutputProperty
+		schemaMap = map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{OutputProperty: schemaMap},
+			"required":   []string{OutputProperty},
+		}
+	}
+
+	if err := addDefinitions(schemaMap, definitions); err != nil {
+		return nil, err
+	}
+
+	raw, err := json.MarshalIndent(schemaMap, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal output schema: %w", err)
+	}
+	output.RawSchema = string(raw)
+	return output, nil
+}
+
+// jsonContentType returns the JSON content type with a schema, application/json first
+func jsonContentType(content openapi3.Content) string {
+	if hasSchema(content["application/json"]) {
+		return "application/json"
+	}
+	for _, contentType := range sortedContentTypes(content) {
+		if isJSONContentType(contentType) && hasSchema(content[contentType]) {
+			return contentType
+		}
+	}
+	return ""
+}
+
+// isJSONContentType reports whether a content type is JSON or a JSON-based media type such as application/problem+json
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = contentType
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
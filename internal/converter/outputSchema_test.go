@@ -0,0 +1,152 @@
+package converter
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const outputSpec = `openapi: 3.0.3
+info:
+  title: Output API
+  version: "1.0.0"
+paths:
+  /todos:
+    get:
+      operationId: listTodos
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  type: string
+    post:
+      operationId: createTodo
+      responses:
+        "400":
+          description: Bad request
+          content:
+            application/json:
+              schema:
+                type: object
+        "201":
+          description: Created
+          content:
+            text/plain:
+              schema:
+                type: string
+            application/vnd.todo+json:
+              schema:
+                type: object
+                properties:
+                  id:
+                    type: integer
+  /todos/{todoId}:
+    delete:
+      operationId: deleteTodo
+      parameters:
+        - name: todoId
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "204":
+          description: Deleted
+    put:
+      operationId: updateTodo
+      parameters:
+        - name: todoId
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: Updated
+          content:
+            application/json:
+              schema:
+                type: object
+        "202":
+          description: Update queued
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  jobId:
+                    type: string
+    patch:
+      operationId: patchTodo
+      parameters:
+        - name: todoId
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: Patched
+          content:
+            application/json:
+              schema:
+                type: object
+        "204":
+          description: Nothing to patch
+`
+
+func TestConverter_Output(t *testing.T) {
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(outputSpec)); err != nil {
+		t.Fatalf("failed to parse OpenAPI: %v", err)
+	}
+	config, err := NewConverter(parser).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	// The other tools have no output: deleteTodo has no JSON body, updateTodo and patchTodo several success responses
+	tests := map[string]struct {
+		statusCode  int
+		contentType string
+		property    string
+		rootType    string // Type of the response schema, found under the property when wrapped
+	}{
+		"createTodo": {201, "application/vnd.todo+json", "", "object"},
+		"listTodos":  {200, "application/json", OutputProperty, "array"},
+	}
+
+	for _, tool := range config.Tools {
+		tt, ok := tests[tool.Name]
+		if !ok {
+			if tool.Output != nil {
+				t.Errorf("%s: expected no output, got %+v", tool.Name, tool.Output)
+			}
+			continue
+		}
+		if tool.Output == nil {
+			t.Fatalf("%s: expected an output", tool.Name)
+		}
+		if tool.Output.StatusCode != tt.statusCode || tool.Output.ContentType != tt.contentType || tool.Output.Property != tt.property {
+			t.Errorf("%s: output = %d %s %q, want %d %s %q", tool.Name,
+				tool.Output.StatusCode, tool.Output.ContentType, tool.Output.Property, tt.statusCode, tt.contentType, tt.property)
+		}
+
+		var schema map[string]any
+		if err := json.Unmarshal([]byte(tool.Output.RawSchema), &schema); err != nil {
+			t.Fatalf("%s: invalid output schema: %v", tool.Name, err)
+		}
+		if schema["type"] != "object" {
+			t.Errorf("%s: output schema type = %v, want object", tool.Name, schema["type"])
+		}
+		if tt.property != "" {
+			schema, _ = schema["properties"].(map[string]any)[tt.property].(map[string]any)
+		}
+		if schema["type"] != tt.rootType {
+			t.Errorf("%s: response schema type = %v, want %s", tool.Name, schema["type"], tt.rootType)
+		}
+	}
+}
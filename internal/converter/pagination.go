@@ -0,0 +1,371 @@
+package converter
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Pagination styles of list operations
+const (
+	PaginationPage   = "page"   // A page number parameter, increased by one for each page
+	PaginationOffset = "offset" // An offset parameter, increased by the number of items received
+	PaginationCursor = "cursor" // A cursor, or the next page URL, read from the response body
+	PaginationLink   = "link"   // The next page URL of the Link response header
+)
+
+// Names of the pagination parameters and response properties detected, compared once normalized by paginationName
+var (
+	pageParams       = []string{"page", "pagenumber", "pageno", "pageindex"}
+	offsetParams     = []string{"offset", "skip", "startindex"}
+	cursorParams     = []string{"cursor", "pagetoken", "nexttoken", "continuationtoken", "after", "startingafter", "pagecursor"}
+	cursorProperties = []string{"nextcursor", "nextpagetoken", "nexttoken", "cursor", "nextpagecursor", "continuationtoken", "endcursor", "next", "nexturl", "nextlink", "nextpage"}
+	itemsProperties  = []string{"items", "data", "results", "records", "entries", "values", "content", "list", "nodes"}
+)
+
+// Arguments added to the tools of paginated operations, with the "pagination" source
+const (
+	MaxPagesArgName     = "maxPages"
+	MaxItemsArgName     = "maxItems"
+	ContinuationArgName = "continuation"
+)
+
+// SetPagination gives the tools of paginated operations the arguments following their pages, see paginationArgs.
+// Only proxy handlers follow the pages, the x-mcp-pagination extensions are validated either way.
+func (c *Converter) SetPagination(enabled bool) {
+	c.pagination = enabled
+}
+
+// createPagination detects how a GET operation returns its items over several pages from its query parameters,
+// its Link response header and its response body. x-mcp-pagination replaces the detected pagination,
+// also on other methods, or disables it with false.
+func createPagination(method string, operation *openapi3.Operation) (*Pagination, error) {
+	value, hasExtension := operation.Extensions[paginationExtension]
+	if enabled, ok := value.(bool); ok {
+		if enabled {
+			return nil, fmt.Errorf("%s must be false or an object", paginationExtension)
+		}
+		return nil, nil
+	}
+	if !hasExtension && !strings.EqualFold(method, http.MethodGet) {
+		return nil, nil
+	}
+
+	body := successBodySchema(operation)
+	pagination := detectPagination(operation, body)
+	if hasExtension {
+		var err error
+		if pagination, err = applyPaginationExtension(pagination, value, body); err != nil {
+			return nil, err
+		}
+	}
+	if pagination != nil && pagination.Style == PaginationPage {
+		pagination.Start = firstPage(operation.Parameters.GetByInAndName("query", pagination.Param))
+	}
+	return pagination, nil
+}
+
+// detectPagination returns the pagination of an operation from the names of its query parameters and response
+// properties, nil when it has none or when its response has no items array to aggregate
+func detectPagination(operation *openapi3.Operation, body *openapi3.Schema) *Pagination {
+	items, ok := itemsPath(body)
+	if !ok {
+		return nil
+	}
+
+	params := make(map[string]string)
+	for _, paramRef := range operation.Parameters {
+		if paramRef == nil || paramRef.Value == nil || paramRef.Value.In != "query" {
+			continue
+		}
+		if name := paginationName(paramRef.Value.Name); params[name] == "" {
+			params[name] = paramRef.Value.Name
+		}
+	}
+	cursor := propertyPath(body, cursorProperties, isStringSchema)
+
+	switch {
+	case cursor != "" && findName(params, cursorParams) != "":
+		return &Pagination{Style: PaginationCursor, Param: findName(params, cursorParams), Cursor: cursor, Items: items}
+	case hasLinkHeader(operation):
+		return &Pagination{Style: PaginationLink, Items: items}
+	case findName(params, pageParams) != "":
+		return &Pagination{Style: PaginationPage, Param: findName(params, pageParams), Items: items}
+	case findName(params, offsetParams) != "":
+		return &Pagination{Style: PaginationOffset, Param: findName(params, offsetParams), Items: items}
+	case cursor != "":
+		// Without a cursor parameter, the cursor is expected to be the URL of the next page
+		return &Pagination{Style: PaginationCursor, Cursor: cursor, Items: items}
+	}
+	return nil
+}
+
+// applyPaginationExtension replaces the detected pagination with the fields of x-mcp-pagination:
+// style, param, cursor and items
+func applyPaginationExtension(detected *Pagination, value any, body *openapi3.Schema) (*Pagination, error) {
+	fields, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s must be false or an object, got %v", paginationExtension, value)
+	}
+
+	pagination := &Pagination{}
+	if detected != nil {
+		*pagination = *detected
+	} else if items, ok := itemsPath(body); ok {
+		pagination.Items = items
+	} else if _, ok := fields["items"]; !ok {
+		return nil, fmt.Errorf("%s.items is required as the response has no items array", paginationExtension)
+	}
+
+	for key, value := range fields {
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s.%s must be a string, got %v", paginationExtension, key, value)
+		}
+		switch key {
+		case "style":
+			// The parameter and cursor detected for another style do not apply
+			if s != pagination.Style {
+				pagination.Param, pagination.Cursor = "", ""
+			}
+			pagination.Style = s
+		case "param", "cursor", "items":
+		default:
+			return nil, fmt.Errorf("unknown %s field %q", paginationExtension, key)
+		}
+	}
+	if param, ok := fields["param"].(string); ok {
+		pagination.Param = param
+	}
+	if cursor, ok := fields["cursor"].(string); ok {
+		pagination.Cursor = cursor
+	}
+	if items, ok := fields["items"].(string); ok {
+		pagination.Items = items
+	}
+
+	switch pagination.Style {
+	case PaginationPage, PaginationOffset:
+		if pagination.Param == "" {
+			return nil, fmt.Errorf("%s.param is required by the %s style", paginationExtension, pagination.Style)
+		}
+	case PaginationCursor:
+		if pagination.Cursor == "" {
+			return nil, fmt.Errorf("%s.cursor is required by the cursor style", paginationExtension)
+		}
+	case PaginationLink:
+	case "":
+		return nil, fmt.Errorf("%s.style is required as no pagination was detected", paginationExtension)
+	default:
+		return nil, fmt.Errorf("%s.style must be page, offset, cursor or link, got %q", paginationExtension, pagination.Style)
+	}
+	return pagination, nil
+}
+
+// paginationArgs returns the arguments letting the model follow the pages of a paginated operation
+func paginationArgs() []Arg {
+	one := 1.0
+	return []Arg{
+		{
+			Name:        MaxPagesArgName,
+			ParamName:   MaxPagesArgName,
+			Description: "Number of pages to fetch and aggregate, the first one only by default",
+			Source:      "pagination",
+			Schema:      &Schema{Types: []string{"integer"}, Default: 1, Number: &NumberValidation{Minimum: &one}},
+		},
+		{
+			Name:        MaxItemsArgName,
+			ParamName:   MaxItemsArgName,
+			Description: "Maximum number of items to return across pages",
+			Source:      "pagination",
+			Schema:      &Schema{Types: []string{"integer"}, Number: &NumberValidation{Minimum: &one}},
+		},
+		{
+			Name:        ContinuationArgName,
+			ParamName:   ContinuationArgName,
+			Description: "Continuation returned by a previous call with the same arguments whose items were truncated, to resume where it stopped",
+			Source:      "pagination",
+			Schema:      &Schema{Types: []string{"string"}},
+		},
+	}
+}
+
+// successBodySchema returns the schema of the first success response with a JSON body
+func successBodySchema(operation *openapi3.Operation) *openapi3.Schema {
+	if operation.Responses == nil {
+		return nil
+	}
+	for _, code := range sortedResponseCodes(operation.Responses) {
+		statusCode, err := strconv.Atoi(code)
+		if err != nil || statusCode < 200 || statusCode >= 300 {
+			continue
+		}
+		responseRef := operation.Responses.Map()[code]
+		if responseRef == nil || responseRef.Value == nil {
+			continue
+		}
+		if contentType := jsonContentType(responseRef.Value.Content); contentType != "" {
+			return responseRef.Value.Content[contentType].Schema.Value
+		}
+	}
+	return nil
+}
+
+// hasLinkHeader reports whether a success response of an operation documents a Link header
+func hasLinkHeader(operation *openapi3.Operation) bool {
+	if operation.Responses == nil {
+		return false
+	}
+	for code, responseRef := range operation.Responses.Map() {
+		if !strings.HasPrefix(code, "2") || responseRef == nil || responseRef.Value == nil {
+			continue
+		}
+		for name := range responseRef.Value.Headers {
+			if strings.EqualFold(name, "Link") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// itemsPath returns the dot path of the items array of a response body, empty when the body is the array.
+// Known names come first, then the only array property, at the top level then one level down.
+func itemsPath(body *openapi3.Schema) (string, bool) {
+	if body == nil {
+		return "", false
+	}
+	if body.Type.Is("array") {
+		return "", true
+	}
+	if path := propertyPath(body, itemsProperties, isArraySchema); path != "" {
+		return path, true
+	}
+
+	properties := schemaProperties(body)
+	if name := onlyProperty(properties, isArraySchema); name != "" {
+		return name, true
+	}
+	for _, name := range sortedPropertyNames(properties) {
+		if nested := onlyProperty(schemaProperties(properties[name]), isArraySchema); nested != "" {
+			return name + "." + nested, true
+		}
+	}
+	return "", false
+}
+
+// propertyPath returns the dot path of the first property with one of the given names and matching schema,
+// at the top level of an object schema then one level down
+func propertyPath(body *openapi3.Schema, names []string, match func(*openapi3.Schema) bool) string {
+	properties := schemaProperties(body)
+	if name := namedProperty(properties, names, match); name != "" {
+		return name
+	}
+	for _, parent := range sortedPropertyNames(properties) {
+		if name := namedProperty(schemaProperties(properties[parent]), names, match); name != "" {
+			return parent + "." + name
+		}
+	}
+	return ""
+}
+
+// namedProperty returns the property with the first of the given names that matches
+func namedProperty(properties map[string]*openapi3.Schema, names []string, match func(*openapi3.Schema) bool) string {
+	normalized := make(map[string]string, len(properties))
+	for _, name := range sortedPropertyNames(properties) {
+		if match(properties[name]) {
+			normalized[paginationName(name)] = name
+		}
+	}
+	return findName(normalized, names)
+}
+
+// onlyProperty returns the single property matching, empty when none or several match
+func onlyProperty(properties map[string]*openapi3.Schema, match func(*openapi3.Schema) bool) string {
+	found := ""
+	for name, schema := range properties {
+		if !match(schema) {
+			continue
+		}
+		if found != "" {
+			return ""
+		}
+		found = name
+	}
+	return found
+}
+
+// schemaProperties returns the properties of an object schema, including the ones of its allOf
+func schemaProperties(schema *openapi3.Schema) map[string]*openapi3.Schema {
+	if schema == nil {
+		return nil
+	}
+	properties := make(map[string]*openapi3.Schema)
+	for _, part := range schema.AllOf {
+		if part != nil {
+			for name, property := range schemaProperties(part.Value) {
+				properties[name] = property
+			}
+		}
+	}
+	for name, property := range schema.Properties {
+		if property != nil && property.Value != nil {
+			properties[name] = property.Value
+		}
+	}
+	return properties
+}
+
+// sortedPropertyNames returns the names of properties in alphabetical order, for a deterministic detection
+func sortedPropertyNames(properties map[string]*openapi3.Schema) []string {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// isArraySchema reports whether a schema may be an array
+func isArraySchema(schema *openapi3.Schema) bool {
+	return schema != nil && schema.Type.Includes("array")
+}
+
+// isStringSchema reports whether a schema may be a string, cursors are strings or untyped
+func isStringSchema(schema *openapi3.Schema) bool {
+	return schema != nil && (schema.Type == nil || len(*schema.Type) == 0 || schema.Type.Includes("string"))
+}
+
+// findName returns the value of the first of the given names found in a map keyed by normalized name
+func findName(normalized map[string]string, names []string) string {
+	for _, name := range names {
+		if found, ok := normalized[name]; ok {
+			return found
+		}
+	}
+	return ""
+}
+
+// paginationName normalizes a parameter or property name, e.g. page_size and pageSize become pagesize
+func paginationName(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "", ".", "").Replace(name))
+}
+
+// firstPage returns the number of the first page from the default or minimum of the page parameter, 1 otherwise
+func firstPage(param *openapi3.Parameter) int {
+	if param == nil || param.Schema == nil || param.Schema.Value == nil {
+		return 1
+	}
+	schema := param.Schema.Value
+	if start, ok := schema.Default.(float64); ok && start >= 0 {
+		return int(start)
+	}
+	if schema.Min != nil && *schema.Min >= 0 {
+		return int(*schema.Min)
+	}
+	return 1
+}
@@ -0,0 +1,308 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+)
+
+const paginationSpec = `openapi: 3.0.3
+info:
+  title: Pagination API
+  version: "1.0.0"
+paths:
+  /todos:
+    get:
+      operationId: listTodos
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+        - name: offset
+          in: query
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: Todos
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  type: object
+  /users:
+    get:
+      operationId: listUsers
+      parameters:
+        - name: page_token
+          in: query
+          schema:
+            type: string
+      responses:
+        "200":
+          description: Users
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  users:
+                    type: array
+                    items:
+                      type: object
+                  next_page_token:
+                    type: string
+  /projects:
+    get:
+      operationId: listProjects
+      parameters:
+        - name: page
+          in: query
+          schema:
+            type: integer
+            minimum: 0
+      responses:
+        "200":
+          description: Projects
+          headers:
+            Link:
+              schema:
+                type: string
+          content:
+            application/json:
+              schema:
+                allOf:
+                  - type: object
+                    properties:
+                      total:
+                        type: integer
+                  - type: object
+                    properties:
+                      data:
+                        type: array
+                        items:
+                          type: object
+  /events:
+    get:
+      operationId: listEvents
+      responses:
+        "200":
+          description: Events
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  result:
+                    type: object
+                    properties:
+                      events:
+                        type: array
+                        items:
+                          type: object
+                  pageInfo:
+                    type: object
+                    properties:
+                      nextUrl:
+                        type: string
+  /pages:
+    get:
+      operationId: listPages
+      parameters:
+        - name: page
+          in: query
+          schema:
+            type: integer
+            default: 0
+      responses:
+        "200":
+          description: Pages
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  entries:
+                    type: array
+                    items:
+                      type: object
+  /search:
+    post:
+      operationId: search
+      x-mcp-pagination:
+        style: cursor
+        cursor: meta.after
+        param: after
+      responses:
+        "200":
+          description: Matches
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  hits:
+                    type: array
+                    items:
+                      type: object
+    get:
+      operationId: quickSearch
+      x-mcp-pagination: false
+      parameters:
+        - name: page
+          in: query
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: Matches
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  type: object
+  /stats:
+    get:
+      operationId: getStats
+      parameters:
+        - name: page
+          in: query
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: Stats
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  count:
+                    type: integer
+`
+
+func TestConverter_Pagination(t *testing.T) {
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(paginationSpec)); err != nil {
+		t.Fatalf("failed to parse OpenAPI: %v", err)
+	}
+	converter := NewConverter(parser)
+	converter.SetPagination(true)
+	config, err := converter.Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	tests := []struct {
+		tool string
+		want *Pagination
+	}{
+		{tool: "listTodos", want: &Pagination{Style: PaginationOffset, Param: "offset"}},
+		{tool: "listUsers", want: &Pagination{Style: PaginationCursor, Param: "page_token", Cursor: "next_page_token", Items: "users"}},
+		{tool: "listProjects", want: &Pagination{Style: PaginationLink, Items: "data"}},
+		{tool: "listEvents", want: &Pagination{Style: PaginationCursor, Cursor: "pageInfo.nextUrl", Items: "result.events"}},
+		{tool: "listPages", want: &Pagination{Style: PaginationPage, Param: "page", Items: "entries"}},
+		{tool: "search", want: &Pagination{Style: PaginationCursor, Param: "after", Cursor: "meta.after", Items: "hits"}},
+		{tool: "quickSearch"},
+		{tool: "getStats"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			for _, tool := range config.Tools {
+				if tool.Name != tt.tool {
+					continue
+				}
+				if (tool.Pagination == nil) != (tt.want == nil) || (tt.want != nil && *tool.Pagination != *tt.want) {
+					t.Errorf("Pagination = %+v, want %+v", tool.Pagination, tt.want)
+				}
+				var paginationArgs []string
+				for _, arg := range tool.Args {
+					if arg.Source == "pagination" {
+						paginationArgs = append(paginationArgs, arg.Name)
+					}
+				}
+				wantArgs := ""
+				if tt.want != nil {
+					wantArgs = "continuation,maxItems,maxPages"
+				}
+				if got := strings.Join(paginationArgs, ","); got != wantArgs {
+					t.Errorf("pagination args = %q, want %q", got, wantArgs)
+				}
+				return
+			}
+			t.Fatalf("tool %s not found", tt.tool)
+		})
+	}
+}
+
+func TestConverter_Pagination_Disabled(t *testing.T) {
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(paginationSpec)); err != nil {
+		t.Fatalf("failed to parse OpenAPI: %v", err)
+	}
+	config, err := NewConverter(parser).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	// Handlers that do not follow the pages get no pagination arguments
+	for _, tool := range config.Tools {
+		if tool.Pagination != nil {
+			t.Errorf("%s: Pagination = %+v, want nil", tool.Name, tool.Pagination)
+		}
+		for _, arg := range tool.Args {
+			if arg.Source == "pagination" {
+				t.Errorf("%s: unexpected pagination argument %s", tool.Name, arg.Name)
+			}
+		}
+	}
+}
+
+func TestApplyPaginationExtension(t *testing.T) {
+	tests := []struct {
+		name     string
+		detected *Pagination
+		value    any
+		want     Pagination
+		wantErr  string
+	}{
+		{
+			name:     "keeps detected fields",
+			detected: &Pagination{Style: PaginationPage, Param: "page", Items: "data"},
+			value:    map[string]any{"items": "data.rows"},
+			want:     Pagination{Style: PaginationPage, Param: "page", Items: "data.rows"},
+		},
+		{
+			name:     "new style drops detected param",
+			detected: &Pagination{Style: PaginationPage, Param: "page"},
+			value:    map[string]any{"style": "link"},
+			want:     Pagination{Style: PaginationLink},
+		},
+		{name: "not an object", value: "cursor", wantErr: "must be false or an object"},
+		{name: "missing items", value: map[string]any{"style": "link"}, wantErr: "items is required"},
+		{name: "missing style", value: map[string]any{"items": ""}, wantErr: "style is required"},
+		{name: "missing param", value: map[string]any{"style": "offset", "items": ""}, wantErr: "param is required by the offset style"},
+		{name: "missing cursor", value: map[string]any{"style": "cursor", "items": ""}, wantErr: "cursor is required"},
+		{name: "unknown style", value: map[string]any{"style": "token", "items": ""}, wantErr: `must be page, offset, cursor or link, got "token"`},
+		{name: "unknown field", value: map[string]any{"limit": "size", "items": ""}, wantErr: `unknown x-mcp-pagination field "limit"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pagination, err := applyPaginationExtension(tt.detected, tt.value, nil)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("applyPaginationExtension() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("applyPaginationExtension() error = %v", err)
+			}
+			if *pagination != tt.want {
+				t.Errorf("pagination = %+v, want %+v", *pagination, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,10 @@ package converter
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -12,55 +16,92 @@ import (
 type Parser struct {
 	doc              *openapi3.T
 	ValidateDocument bool
+	// CacheDir stores remote documents so that later runs read them from disk instead of the network
+	CacheDir string
+	// HTTPClient fetches remote documents, http.DefaultClient is used when nil
+	HTTPClient *http.Client
+	// openapi31 is set while loading a 3.1 document, so that the documents it references are normalized too
+	openapi31 bool
 }
 
 // NewParser creates a new OpenAPI parser
 func NewParser(validation bool) *Parser {
 	return &Parser{
-		ValidateDocument: validation, // Default to no validation because there are the parser does not handle OpenAPI 3.1
+		ValidateDocument: validation, // Default to no validation because 3.1 documents are normalized to 3.0 before loading and may not fully validate
 	}
 }
 
 // ParseFile parses an OpenAPI document from a file
 func (p *Parser) ParseFile(filePath string) error {
-	loader := openapi3.NewLoader()
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+
+	// Keep the file location so that relative references still resolve
+	return p.load(data, &url.URL{Path: filepath.ToSlash(filePath)})
+}
 
-	// Load directly from file path - this handles streaming internally
-	doc, err := loader.LoadFromFile(filePath)
+// ParseLocation parses an OpenAPI document from a file path or an http(s):// or file:// URL
+func (p *Parser) ParseLocation(location string) error {
+	u, err := parseLocation(location)
 	if err != nil {
 		return fmt.Errorf("failed to parse OpenAPI document: %w", err)
 	}
 
-	// Validate if needed
-	if p.ValidateDocument {
-		err = doc.Validate(context.Background())
-		if err != nil {
-			return fmt.Errorf("invalid OpenAPI document: %w", err)
-		}
+	data, err := p.readFromURI(nil, u)
+	if err != nil {
+		return fmt.Errorf("failed to parse OpenAPI document: %w", err)
 	}
 
-	p.doc = doc
-	return nil
+	// References are resolved relative to the document location
+	return p.load(data, u)
 }
 
 // Parse parses an OpenAPI document from bytes
 func (p *Parser) Parse(data []byte) error {
+	return p.load(data, nil)
+}
+
+// load loads an OpenAPI 3.0, OpenAPI 3.1 or Swagger 2.0 document.
+// Swagger 2.0 documents are upgraded and 3.1 documents are rewritten to 3.0 before loading.
+func (p *Parser) load(data []byte, location *url.URL) error {
 	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = p.readFromURI
 
-	// Try to parse as JSON first
 	var doc *openapi3.T
 	var err error
 
-	// Parse the document (loader can handle both JSON and YAML)
-	doc, err = loader.LoadFromData(data)
+	if isSwagger2(data) {
+		doc, err = convertSwagger2(loader, data, location)
+	} else {
+		data, p.openapi31, err = normalizeOpenAPI31(data)
+		if err != nil {
+			return fmt.Errorf("failed to parse OpenAPI document: %w", err)
+		}
 
+		// Parse the document (loader can handle both JSON and YAML)
+		if location != nil {
+			doc, err = loader.LoadFromDataWithPath(data, location)
+		} else {
+			doc, err = loader.LoadFromData(data)
+		}
+	}
 	if err != nil {
 		return fmt.Errorf("failed to parse OpenAPI document: %w", err)
 	}
 
-	// Validate the document if validation is enabled
+	// Move schemas from other documents into components so the result is self-contained
+	doc.InternalizeRefs(context.Background(), nil)
+
+	return p.setDocument(doc)
+}
+
+// setDocument validates the loaded document if validation is enabled and stores it
+func (p *Parser) setDocument(doc *openapi3.T) error {
 	if p.ValidateDocument {
-		err = doc.Validate(context.Background())
+		err := doc.Validate(context.Background())
 		if err != nil {
 			return fmt.Errorf("invalid OpenAPI document: %w", err)
 		}
@@ -0,0 +1,95 @@
+package converter
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// applyPolicyExtensions sets the timeout and retries of the upstream request from x-mcp-timeout and x-mcp-retry.
+// x-mcp-retry is a number of attempts, false to disable retries, or an object with maxAttempts,
+// initialBackoff and maxBackoff.
+func applyPolicyExtensions(policy *RequestPolicy, extensions map[string]any) error {
+	if value, ok := extensions[timeoutExtension]; ok {
+		timeout, err := extensionDuration(value)
+		if err != nil {
+			return fmt.Errorf("%s %w", timeoutExtension, err)
+		}
+		policy.Timeout = timeout
+	}
+
+	value, ok := extensions[retryExtension]
+	if !ok {
+		return nil
+	}
+	switch retry := value.(type) {
+	case bool:
+		if !retry {
+			policy.MaxAttempts = 1
+		}
+	case map[string]any:
+		for key, value := range retry {
+			var err error
+			switch key {
+			case "maxAttempts":
+				policy.MaxAttempts, err = extensionAttempts(value)
+			case "initialBackoff":
+				policy.InitialBackoff, err = extensionDuration(value)
+			case "maxBackoff":
+				policy.MaxBackoff, err = extensionDuration(value)
+			default:
+				return fmt.Errorf("unknown %s field %q", retryExtension, key)
+			}
+			if err != nil {
+				return fmt.Errorf("%s.%s %w", retryExtension, key, err)
+			}
+		}
+	default:
+		attempts, err := extensionAttempts(value)
+		if err != nil {
+			return fmt.Errorf("%s %w", retryExtension, err)
+		}
+		policy.MaxAttempts = attempts
+	}
+	return nil
+}
+
+// extensionDuration reads a positive duration given as a string such as 1.5s, or as a number of seconds
+func extensionDuration(value any) (time.Duration, error) {
+	var duration time.Duration
+	switch v := value.(type) {
+	case string:
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, fmt.Errorf("must be a duration such as 30s: %w", err)
+		}
+		duration = parsed
+	case float64:
+		duration = time.Duration(v * float64(time.Second))
+	case int:
+		duration = time.Duration(v) * time.Second
+	default:
+		return 0, fmt.Errorf("must be a duration such as 30s or a number of seconds, got %v", value)
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("must be positive, got %v", value)
+	}
+	return duration, nil
+}
+
+// extensionAttempts reads a number of attempts, at least 1
+func extensionAttempts(value any) (int, error) {
+	var attempts float64
+	switch v := value.(type) {
+	case float64:
+		attempts = v
+	case int:
+		attempts = float64(v)
+	default:
+		return 0, fmt.Errorf("must be a number of attempts, got %v", value)
+	}
+	if attempts < 1 || attempts != math.Trunc(attempts) {
+		return 0, fmt.Errorf("must be a whole number of attempts of at least 1, got %v", value)
+	}
+	return int(attempts), nil
+}
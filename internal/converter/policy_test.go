@@ -0,0 +1,70 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const policySpec = `openapi: 3.0.3
+info:
+  title: Policy API
+  version: "1.0.0"
+paths:
+  /reports:
+    post:
+      operationId: createReport
+      x-mcp-timeout: 2m
+      x-mcp-retry:
+        maxAttempts: 5
+        initialBackoff: 0.25
+        maxBackoff: 10s
+      responses:
+        "202":
+          description: Accepted
+`
+
+func TestConverter_PolicyExtensions(t *testing.T) {
+	tool := convertExtensionsSpec(t, policySpec)
+
+	want := RequestPolicy{Timeout: 2 * time.Minute, MaxAttempts: 5, InitialBackoff: 250 * time.Millisecond, MaxBackoff: 10 * time.Second}
+	if tool.Policy != want {
+		t.Errorf("Policy = %+v, want %+v", tool.Policy, want)
+	}
+}
+
+func TestApplyPolicyExtensions(t *testing.T) {
+	tests := []struct {
+		name       string
+		extensions map[string]any
+		want       RequestPolicy
+		wantErr    string
+	}{
+		{name: "none"},
+		{name: "timeout in seconds", extensions: map[string]any{"x-mcp-timeout": float64(45)}, want: RequestPolicy{Timeout: 45 * time.Second}},
+		{name: "attempts", extensions: map[string]any{"x-mcp-retry": float64(4)}, want: RequestPolicy{MaxAttempts: 4}},
+		{name: "disabled", extensions: map[string]any{"x-mcp-retry": false}, want: RequestPolicy{MaxAttempts: 1}},
+		{name: "invalid timeout", extensions: map[string]any{"x-mcp-timeout": "soon"}, wantErr: "x-mcp-timeout must be a duration"},
+		{name: "negative timeout", extensions: map[string]any{"x-mcp-timeout": "-1s"}, wantErr: "x-mcp-timeout must be positive"},
+		{name: "fractional attempts", extensions: map[string]any{"x-mcp-retry": 2.5}, wantErr: "x-mcp-retry must be a whole number"},
+		{name: "unknown field", extensions: map[string]any{"x-mcp-retry": map[string]any{"attempts": float64(3)}}, wantErr: `unknown x-mcp-retry field "attempts"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var policy RequestPolicy
+			err := applyPolicyExtensions(&policy, tt.extensions)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("applyPolicyExtensions() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("applyPolicyExtensions() error = %v", err)
+			}
+			if policy != tt.want {
+				t.Errorf("policy = %+v, want %+v", policy, tt.want)
+			}
+		})
+	}
+}
@@ -20,20 +20,29 @@ func (c *Converter) convertRequestBody(requestBodyRef *openapi3.RequestBodyRef)
 		Required:     requestBody.Required,
 		ContentTypes: make(map[string]*Schema),
 	}
+	description := extensionString(requestBody.Extensions, descriptionExtension)
+	if description != "" {
+		Arg.Description = description
+	}
 
 	// Process each content type
 	validContent := false
-	for contentType, mediaType := range requestBody.Content {
+	for _, contentType := range c.sortContentTypes(requestBody.Content) {
+		mediaType := requestBody.Content[contentType]
 		if mediaType == nil || mediaType.Schema == nil || mediaType.Schema.Value == nil {
 			continue
 		}
 
-		schema, err := c.applySchema(mediaType.Schema.Value)
+		schema, err := c.applySchemaRef(mediaType.Schema)
 		if err != nil {
 			return nil, fmt.Errorf("failed to convert schema for content type %s: %w", contentType, err)
 		}
 
 		if schema != nil {
+			// The schema description takes precedence in the input schema
+			if description != "" {
+				schema.Description = description
+			}
 			Arg.ContentTypes[contentType] = schema
 			validContent = true
 		}
@@ -63,7 +72,7 @@ func (c *Converter) convertParameters(parameters openapi3.Parameters) ([]Arg, er
 		}
 
 		// Convert the schema using our new function
-		schema, err := c.applySchema(param.Schema.Value)
+		schema, err := c.applySchemaRef(param.Schema)
 		if err != nil {
 			return nil, fmt.Errorf("failed to convert schema for parameter %s (index %d): %w",
 				param.Name, i, err)
@@ -72,12 +81,17 @@ func (c *Converter) convertParameters(parameters openapi3.Parameters) ([]Arg, er
 		// Create an arg for this parameter
 		arg := Arg{
 			Name:        param.Name,
+			ParamName:   param.Name,
 			Description: param.Description,
 			Source:      param.In,
 			Required:    param.Required,
 			Schema:      schema,
 			Deprecated:  param.Deprecated,
 		}
+		if method, err := param.SerializationMethod(); err == nil {
+			arg.Style, arg.Explode = method.Style, method.Explode
+		}
+		applyParameterExtensions(&arg, param)
 
 		args = append(args, arg)
 	}
@@ -12,6 +12,15 @@ func (c *Converter) applySchema(schema *openapi3.Schema) (*Schema, error) {
 		return nil, fmt.Errorf("cannot apply metadata to nil schema")
 	}
 
+	// References back to a schema being converted are recursive
+	if !c.converting[schema] {
+		if c.converting == nil {
+			c.converting = make(map[*openapi3.Schema]bool)
+		}
+		c.converting[schema] = true
+		defer delete(c.converting, schema)
+	}
+
 	// Create a new Schema
 	result := &Schema{
 		Title:       schema.Title,
@@ -23,6 +32,7 @@ func (c *Converter) applySchema(schema *openapi3.Schema) (*Schema, error) {
 		ReadOnly:    schema.ReadOnly,
 		WriteOnly:   schema.WriteOnly,
 	}
+	applySchemaExtensions(result, schema)
 
 	// Handle types, including nullable
 	if schema.Type != nil {
@@ -80,7 +90,7 @@ func (c *Converter) applySchema(schema *openapi3.Schema) (*Schema, error) {
 			if subSchemaRef == nil || subSchemaRef.Value == nil {
 				return nil, fmt.Errorf("oneOf contains a nil schema reference or value at index %d", i)
 			}
-			subSchema, err := c.applySchema(subSchemaRef.Value) // Recursive call
+			subSchema, err := c.applySchemaRef(subSchemaRef) // Recursive call
 			if err != nil {
 				return nil, fmt.Errorf("error processing oneOf sub-schema at index %d: %w", i, err)
 			}
@@ -101,7 +111,7 @@ func (c *Converter) applySchema(schema *openapi3.Schema) (*Schema, error) {
 			if subSchemaRef == nil || subSchemaRef.Value == nil {
 				return nil, fmt.Errorf("anyOf contains a nil schema reference or value at index %d", i)
 			}
-			subSchema, err := c.applySchema(subSchemaRef.Value)
+			subSchema, err := c.applySchemaRef(subSchemaRef)
 			if err != nil {
 				return nil, fmt.Errorf("error processing anyOf sub-schema at index %d: %w", i, err)
 			}
@@ -120,7 +130,7 @@ func (c *Converter) applySchema(schema *openapi3.Schema) (*Schema, error) {
 			if subSchemaRef == nil || subSchemaRef.Value == nil {
 				return nil, fmt.Errorf("allOf contains a nil schema reference or value at index %d", i)
 			}
-			subSchema, err := c.applySchema(subSchemaRef.Value)
+			subSchema, err := c.applySchemaRef(subSchemaRef)
 			if err != nil {
 				return nil, fmt.Errorf("error processing allOf sub-schema at index %d: %w", i, err)
 			}
@@ -134,7 +144,7 @@ func (c *Converter) applySchema(schema *openapi3.Schema) (*Schema, error) {
 
 	// Handle Not
 	if schema.Not != nil && schema.Not.Value != nil {
-		notSchema, err := c.applySchema(schema.Not.Value)
+		notSchema, err := c.applySchemaRef(schema.Not)
 		if err != nil {
 			return nil, fmt.Errorf("error processing not sub-schema: %w", err)
 		}
@@ -186,7 +196,7 @@ func (c *Converter) createArrayValidation(schema *openapi3.Schema) (*ArrayValida
 	}
 
 	if schema.Items != nil && schema.Items.Value != nil {
-		itemsSchema, err := c.applySchema(schema.Items.Value)
+		itemsSchema, err := c.applySchemaRef(schema.Items)
 		if err != nil {
 			return nil, fmt.Errorf("error processing array items schema: %w", err)
 		}
@@ -211,9 +221,14 @@ func (c *Converter) createObjectValidation(schema *openapi3.Schema) (*ObjectVali
 	if len(schema.Properties) > 0 {
 		result.Properties = make(map[string]*Schema)
 		for propName, propSchemaRef := range schema.Properties {
+			// Properties hidden from clients are left to the upstream API defaults
+			if propSchemaRef != nil && propSchemaRef.Value != nil && extensionBool(propSchemaRef.Value.Extensions, hiddenExtension) {
+				result.Required = removeString(result.Required, propName)
+				continue
+			}
 			if propSchemaRef != nil {
 				if propSchemaRef.Value != nil {
-					propSchema, err := c.applySchema(propSchemaRef.Value)
+					propSchema, err := c.applySchemaRef(propSchemaRef)
 					if err != nil {
 						return nil, fmt.Errorf("error processing property '%s': %w", propName, err)
 					}
@@ -244,7 +259,7 @@ func (c *Converter) createObjectValidation(schema *openapi3.Schema) (*ObjectVali
 	} else if schema.AdditionalProperties.Schema != nil {
 		// Case 2: additionalProperties is a schema object (or meant to be)
 		if schema.AdditionalProperties.Schema.Value != nil {
-			addPropSchema, err := c.applySchema(schema.AdditionalProperties.Schema.Value)
+			addPropSchema, err := c.applySchemaRef(schema.AdditionalProperties.Schema)
 			if err != nil {
 				return nil, fmt.Errorf("error processing additionalProperties schema: %w", err)
 			}
@@ -162,3 +162,33 @@ func TestConvertParameters_SkipInvalid(t *testing.T) {
 		t.Errorf("expected 0 args, got %d", len(args))
 	}
 }
+
+func TestConvertParameters_Style(t *testing.T) {
+	explode := false
+	tests := []struct {
+		name        string
+		param       *openapi3.Parameter
+		wantStyle   string
+		wantExplode bool
+	}{
+		{"query default", &openapi3.Parameter{Name: "q", In: "query"}, "form", true},
+		{"path default", &openapi3.Parameter{Name: "id", In: "path"}, "simple", false},
+		{"header default", &openapi3.Parameter{Name: "X-Id", In: "header"}, "simple", false},
+		{"cookie default", &openapi3.Parameter{Name: "session", In: "cookie"}, "form", true},
+		{"deepObject", &openapi3.Parameter{Name: "filter", In: "query", Style: "deepObject"}, "deepObject", true},
+		{"pipeDelimited", &openapi3.Parameter{Name: "tags", In: "query", Style: "pipeDelimited", Explode: &explode}, "pipeDelimited", false},
+		{"matrix", &openapi3.Parameter{Name: "id", In: "path", Style: "matrix"}, "matrix", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.param.Schema = &openapi3.SchemaRef{Value: &openapi3.Schema{}}
+			args, err := (&Converter{}).convertParameters(openapi3.Parameters{&openapi3.ParameterRef{Value: tt.param}})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if args[0].Style != tt.wantStyle || args[0].Explode != tt.wantExplode {
+				t.Errorf("style = %q, explode = %v, want %q, %v", args[0].Style, args[0].Explode, tt.wantStyle, tt.wantExplode)
+			}
+		})
+	}
+}
@@ -48,8 +48,10 @@ func (c *Converter) convertOperation(path, method string, operation *openapi3.Op
 	tool := &Tool{
 		Name:        toolName,
 		Description: getDescription(operation),
+		Tags:        operation.Tags,
 		Args:        []Arg{},
 	}
+	applyOperationExtensions(tool, operation)
 
 	// Convert parameters to arguments
 	args, err := c.convertParameters(operation.Parameters)
@@ -66,20 +68,54 @@ func (c *Converter) convertOperation(path, method string, operation *openapi3.Op
 		return nil, fmt.Errorf("failed to convert request body: %w", err)
 	}
 	if bodyArgs != nil {
-		tool.Args = append(tool.Args, *bodyArgs)
+		if flattened := c.flattenBodyArg(*bodyArgs); flattened != nil {
+			tool.Args = append(tool.Args, flattened...)
+		} else {
+			tool.Args = append(tool.Args, *bodyArgs)
+		}
+		// Let the model pick the content type of bodies offering several
+		if selector := contentTypeArg(*bodyArgs, c.sortContentTypes(operation.RequestBody.Value.Content)); selector != nil {
+			tool.Args = append(tool.Args, *selector)
+		}
 	}
 
-	rawInputSchema, err := GenerateJSONSchemaDraft7(tool.Args)
+	// Let the model follow the pages of list operations
+	pagination, err := createPagination(method, operation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pagination: %w", err)
+	}
+	if pagination != nil && c.pagination {
+		tool.Pagination = pagination
+		tool.Args = append(tool.Args, paginationArgs()...)
+	}
+
+	if err := hideArgs(tool, operation); err != nil {
+		return nil, fmt.Errorf("failed to hide parameters: %w", err)
+	}
+
+	// Hidden arguments are matched by parameter name, the visible ones get their final names afterwards
+	resolveArgNames(tool.Args)
+
+	tool.Definitions = c.referencedDefinitions(argSchemas(tool.Args)...)
+	examples := renameExampleArguments(extensionList(operation.Extensions, examplesExtension), tool.Args)
+	rawInputSchema, err := generateInputSchema(tool.Args, examples, tool.Definitions)
 	if err != nil {
 		return nil, fmt.Errorf("failed creating raw input schema for the %s tool input", toolName)
 	}
 
-	tool.RawInputSchema = rawInputSchema
+	// Simplify the input schema when it exceeds the size budget of the tool
+	tool.RawInputSchema, tool.SchemaSimplifications, err = c.fitSchemaBudget(toolName, rawInputSchema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fit the %s tool input schema to its budget: %w", toolName, err)
+	}
 
 	// Sort arguments by name for consistent output
 	sort.Slice(tool.Args, func(i, j int) bool {
 		return tool.Args[i].Name < tool.Args[j].Name
 	})
+	sort.Slice(tool.HiddenArgs, func(i, j int) bool {
+		return tool.HiddenArgs[i].Name < tool.HiddenArgs[j].Name
+	})
 
 	// Create request template
 	requestTemplate, err := c.createRequestTemplate(path, method, operation)
@@ -88,12 +124,30 @@ func (c *Converter) convertOperation(path, method string, operation *openapi3.Op
 	}
 	tool.RequestTemplate = *requestTemplate
 
+	// Derive the annotations from the HTTP method, the extension has the final say
+	tool.Annotations = deriveAnnotations(tool.RequestTemplate.Method)
+	tool.Annotations.Title = operation.Summary
+	if err := applyAnnotationsExtension(&tool.Annotations, operation.Extensions); err != nil {
+		return nil, fmt.Errorf("failed to apply annotations: %w", err)
+	}
+	if err := applyPolicyExtensions(&tool.Policy, operation.Extensions); err != nil {
+		return nil, fmt.Errorf("failed to apply request policy: %w", err)
+	}
+
 	// Create response template
 	responseTemplate, err := c.createResponseTemplates(operation)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create response template: %w", err)
 	}
 	tool.Responses = responseTemplate
+	tool.Errors = createErrorDescriptions(operation)
+
+	// Expose the primary success response as structured content
+	output, err := c.createOutput(operation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output schema: %w", err)
+	}
+	tool.Output = output
 
 	return tool, nil
 }
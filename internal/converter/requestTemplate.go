@@ -17,26 +17,41 @@ func (c *Converter) createRequestTemplate(path, method string, operation *openap
 	// Remove trailing slash from server URL if present
 	serverURL = strings.TrimSuffix(serverURL, "/")
 
+	security, err := c.createSecurityRequirements(operation)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create the request template
 	template := &RequestTemplate{
-		URL:     serverURL + path,
-		Method:  strings.ToUpper(method),
-		Headers: []Header{},
+		URL:      serverURL + path,
+		Path:     path,
+		Method:   strings.ToUpper(method),
+		Headers:  []Header{},
+		Security: security,
 	}
 
-	// Add Content-Type header based on request body content type
+	// Add the Content-Type header of the preferred request body content type
 	if operation.RequestBody != nil && operation.RequestBody.Value != nil {
-		for contentType := range operation.RequestBody.Value.Content {
-			// Add the Content-Type header
-			template.Headers = append(template.Headers, Header{
-				Key:   "Content-Type",
-				Value: contentType,
-			})
-			break // Just use the first content type
+		content := operation.RequestBody.Value.Content
+		template.ContentTypes = c.sortContentTypes(content)
+		template.BodyRequired = operation.RequestBody.Value.Required
+		for i, contentType := range template.ContentTypes {
+			if i == 0 {
+				template.Headers = append(template.Headers, Header{
+					Key:   "Content-Type",
+					Value: contentType,
+				})
+				template.ArgsToFormBody = isFormContentType(contentType)
+			}
+			if encoding := bodyEncoding(contentType, content[contentType]); encoding.Binary || encoding.Fields != nil {
+				if template.BodyEncodings == nil {
+					template.BodyEncodings = make(map[string]BodyEncoding)
+				}
+				template.BodyEncodings[contentType] = encoding
+			}
 		}
 	}
 
 	return template, nil
 }
-
-
@@ -37,6 +37,10 @@ func TestCreateRequestTemplate_Basic(t *testing.T) {
 	if template.URL != "http://api.example.com/v1/hello" {
 		t.Errorf("expected URL 'http://api.example.com/v1/hello', got %q", template.URL)
 	}
+	// Path should keep the template relative to the server URL
+	if template.Path != "/v1/hello" {
+		t.Errorf("expected Path '/v1/hello', got %q", template.Path)
+	}
 	// Method should be uppercase
 	if template.Method != "POST" {
 		t.Errorf("expected method POST, got %q", template.Method)
@@ -0,0 +1,127 @@
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DefaultResourceScheme is the URI scheme of the generated resources
+const DefaultResourceScheme = "openapi"
+
+// ResourceOptions selects the parts of the specification exposed as MCP resources
+type ResourceOptions struct {
+	// Operations turns GET operations whose arguments are all path parameters into
+	// resources, or resource templates when the path has parameters, instead of tools
+	Operations bool `json:"operations,omitempty"`
+	// Schemas exposes every components.schemas entry as a static documentation resource
+	Schemas bool `json:"schemas,omitempty"`
+	// Scheme of the resource URIs, DefaultResourceScheme when empty
+	Scheme string `json:"scheme,omitempty"`
+}
+
+// uriScheme matches valid URI schemes
+var uriScheme = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9+.-]*$`)
+
+// uriTemplateVariable matches the path parameter names that are valid URI template variables
+var uriTemplateVariable = regexp.MustCompile(`^[A-Za-z0-9_]+(\.[A-Za-z0-9_]+)*$`)
+
+// SetResourceOptions configures which operations and schemas become resources
+func (c *Converter) SetResourceOptions(options ResourceOptions) error {
+	if options.Scheme != "" && !uriScheme.MatchString(options.Scheme) {
+		return fmt.Errorf("invalid resource URI scheme %q", options.Scheme)
+	}
+	c.resources = options
+	return nil
+}
+
+// resourceScheme returns the URI scheme of the resources
+func (c *Converter) resourceScheme() string {
+	if c.resources.Scheme != "" {
+		return c.resources.Scheme
+	}
+	return DefaultResourceScheme
+}
+
+// operationResource returns the resource reading a converted GET operation,
+// or nil when the operation has to stay a tool
+func (c *Converter) operationResource(path string, tool *Tool) *Resource {
+	if !c.resources.Operations || tool.RequestTemplate.Method != "GET" {
+		return nil
+	}
+
+	// Only path parameters can be filled from the URI, under their own name.
+	// Reading a paginated list returns its first page, the pagination arguments are left out.
+	var args []Arg
+	for _, arg := range tool.Args {
+		if arg.Source == "pagination" {
+			continue
+		}
+		if arg.Source != "path" || arg.Name != arg.ParamName || !uriTemplateVariable.MatchString(arg.Name) {
+			return nil
+		}
+		args = append(args, arg)
+	}
+	tool.Args, tool.Pagination = args, nil
+
+	return &Resource{
+		Name:        tool.Name,
+		URI:         c.resourceScheme() + "://" + strings.TrimPrefix(path, "/"),
+		Template:    len(args) > 0,
+		Description: tool.Description,
+		MIMEType:    successContentType(tool.Responses),
+		Tool:        tool,
+	}
+}
+
+// schemaResources returns the component schemas as static documentation resources
+func (c *Converter) schemaResources() ([]Resource, error) {
+	doc := c.parser.GetDocument()
+	if !c.resources.Schemas || doc.Components == nil {
+		return nil, nil
+	}
+
+	var resources []Resource
+	for name, schemaRef := range doc.Components.Schemas {
+		if schemaRef == nil || schemaRef.Value == nil {
+			continue
+		}
+
+		// Nested schemas stay references to the other schema resources
+		text, err := json.MarshalIndent(schemaRef.Value, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode schema %s: %w", name, err)
+		}
+
+		description := schemaRef.Value.Description
+		if description == "" {
+			description = schemaRef.Value.Title
+		}
+		// Component names are escaped as they may hold spaces or characters reserved in URIs
+		resources = append(resources, Resource{
+			Name:        name,
+			URI:         c.resourceScheme() + "://schemas/" + url.PathEscape(name),
+			Description: description,
+			MIMEType:    "application/json",
+			Text:        string(text),
+		})
+	}
+
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].Name < resources[j].Name
+	})
+	return resources, nil
+}
+
+// successContentType returns the content type of the first successful response, JSON by default
+func successContentType(responses []ResponseTemplate) string {
+	for _, response := range responses {
+		if response.StatusCode >= 200 && response.StatusCode < 300 && response.ContentType != "" {
+			return response.ContentType
+		}
+	}
+	return "application/json"
+}
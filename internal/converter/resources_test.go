@@ -0,0 +1,173 @@
+package converter
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const resourcesSpec = `openapi: 3.0.3
+info:
+  title: Resources API
+  version: "1.0.0"
+paths:
+  /todos:
+    get:
+      operationId: listTodos
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: "#/components/schemas/Todo"
+    post:
+      operationId: createTodo
+      responses:
+        "201":
+          description: Created
+  /todos/{todoId}:
+    get:
+      operationId: getTodo
+      summary: Get a todo
+      parameters:
+        - name: todoId
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: OK
+          content:
+            text/markdown:
+              schema:
+                type: string
+  /events:
+    get:
+      operationId: listEvents
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  data:
+                    type: array
+                    items:
+                      type: object
+                  next:
+                    type: string
+  /search:
+    get:
+      operationId: searchTodos
+      parameters:
+        - name: q
+          in: query
+          schema:
+            type: string
+      responses:
+        "200":
+          description: OK
+components:
+  schemas:
+    Todo:
+      type: object
+      description: A todo item
+      properties:
+        title:
+          type: string
+    Todo List:
+      type: array
+      title: A list of todos
+      items:
+        $ref: "#/components/schemas/Todo"
+`
+
+func TestConverter_Resources(t *testing.T) {
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(resourcesSpec)); err != nil {
+		t.Fatalf("failed to parse OpenAPI: %v", err)
+	}
+	converter := NewConverter(parser)
+	converter.SetPagination(true)
+	if err := converter.SetResourceOptions(ResourceOptions{Operations: true, Schemas: true, Scheme: "todo"}); err != nil {
+		t.Fatalf("SetResourceOptions failed: %v", err)
+	}
+	config, err := converter.Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	var tools []string
+	for _, tool := range config.Tools {
+		tools = append(tools, tool.Name)
+	}
+	if len(tools) != 2 || tools[0] != "createTodo" || tools[1] != "searchTodos" {
+		t.Errorf("Tools = %v, want [createTodo searchTodos]", tools)
+	}
+
+	tests := []struct {
+		name     string
+		uri      string
+		template bool
+		mimeType string
+		static   bool
+	}{
+		{"getTodo", "todo://todos/{todoId}", true, "text/markdown", false},
+		// A paginated list reads its first page
+		{"listEvents", "todo://events", false, "application/json", false},
+		{"listTodos", "todo://todos", false, "application/json", false},
+		{"Todo", "todo://schemas/Todo", false, "application/json", true},
+		{"Todo List", "todo://schemas/Todo%20List", false, "application/json", true},
+	}
+	if len(config.Resources) != len(tests) {
+		t.Fatalf("got %d resources, want %d", len(config.Resources), len(tests))
+	}
+	for i, tt := range tests {
+		resource := config.Resources[i]
+		if resource.Name != tt.name || resource.URI != tt.uri || resource.Template != tt.template || resource.MIMEType != tt.mimeType {
+			t.Errorf("resource %d = %s %s template=%v %s, want %s %s template=%v %s", i,
+				resource.Name, resource.URI, resource.Template, resource.MIMEType, tt.name, tt.uri, tt.template, tt.mimeType)
+		}
+		if (resource.Tool == nil) != tt.static {
+			t.Errorf("resource %s: static = %v, want %v", resource.Name, resource.Tool == nil, tt.static)
+		}
+	}
+
+	if events := config.Resources[1].Tool; len(events.Args) != 0 || events.Pagination != nil {
+		t.Errorf("listEvents resource args = %v, pagination = %+v, want none", events.Args, events.Pagination)
+	}
+
+	schema := config.Resources[3]
+	if schema.Description != "A todo item" {
+		t.Errorf("schema description = %q, want %q", schema.Description, "A todo item")
+	}
+	var text map[string]any
+	if err := json.Unmarshal([]byte(schema.Text), &text); err != nil || text["type"] != "object" {
+		t.Errorf("schema text = %s, want the JSON schema (%v)", schema.Text, err)
+	}
+}
+
+func TestConverter_Resources_Disabled(t *testing.T) {
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(resourcesSpec)); err != nil {
+		t.Fatalf("failed to parse OpenAPI: %v", err)
+	}
+	config, err := NewConverter(parser).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if len(config.Tools) != 5 || len(config.Resources) != 0 {
+		t.Errorf("got %d tools and %d resources, want 5 tools and no resources", len(config.Tools), len(config.Resources))
+	}
+}
+
+func TestSetResourceOptions_InvalidScheme(t *testing.T) {
+	if err := NewConverter(NewParser(false)).SetResourceOptions(ResourceOptions{Scheme: "to do"}); err == nil {
+		t.Error("expected an error for an invalid scheme")
+	}
+}
@@ -56,6 +56,17 @@ func (c *Converter) writeSchemaMarkdown(
 		}
 	}
 
+	// A schema nested in itself is documented once
+	if c.documenting[schema] {
+		b.WriteString(fmt.Sprintf("%s  - Recursive: same structure as the enclosing schema above\n", ind))
+		return
+	}
+	if c.documenting == nil {
+		c.documenting = make(map[*openapi3.Schema]bool)
+	}
+	c.documenting[schema] = true
+	defer delete(c.documenting, schema)
+
 	c.writeSchemaDetails(b, schema, indent+1)
 	c.writeSchemaProperties(b, schema, indent)
 	c.writeSchemaCombinators(b, schema, indent)
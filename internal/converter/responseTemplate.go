@@ -35,6 +35,7 @@ func (c *Converter) createResponseTemplates(
 			templates = append(templates, ResponseTemplate{
 				PrependBody: markdown,
 				StatusCode:  statusCode,
+				Code:        code,
 				ContentType: contentType,
 			})
 		}
@@ -0,0 +1,297 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// SchemaBudget limits the size of the tool input schemas, measured as compact JSON as sent to clients
+type SchemaBudget struct {
+	MaxBytes int            // Budget of every tool, 0 for no limit
+	Tools    map[string]int // Budgets by operationId, taking precedence over MaxBytes
+}
+
+// Simplification limits, applied in order until the schema fits the budget
+const (
+	longDescriptionLength  = 200
+	shortDescriptionLength = 80
+	maxEnumValues          = 10
+	listedEnumValues       = 5 // Values listed in the description of the enums over maxEnumValues
+)
+
+// SetSchemaBudget limits the size of the tool input schemas, larger schemas are simplified
+func (c *Converter) SetSchemaBudget(budget SchemaBudget) error {
+	if budget.MaxBytes < 0 {
+		return fmt.Errorf("schema budget must not be negative, got %d", budget.MaxBytes)
+	}
+	for tool, maxBytes := range budget.Tools {
+		if maxBytes < 0 {
+			return fmt.Errorf("schema budget of tool %s must not be negative, got %d", tool, maxBytes)
+		}
+	}
+	c.schemaBudget = budget
+	return nil
+}
+
+// budgetOf returns the schema budget of a tool, 0 for no limit
+func (c *Converter) budgetOf(toolName string) int {
+	if maxBytes, ok := c.schemaBudget.Tools[toolName]; ok {
+		return maxBytes
+	}
+	return c.schemaBudget.MaxBytes
+}
+
+// fitSchemaBudget progressively simplifies an input schema over the budget of the tool:
+// examples are dropped, descriptions truncated, long enum lists replaced by a description listing the first values,
+// then deeply nested schemas collapsed into a summary. It returns the schema and what was simplified.
+func (c *Converter) fitSchemaBudget(toolName, rawSchema string) (string, []string, error) {
+	budget := c.budgetOf(toolName)
+	if budget == 0 {
+		return rawSchema, nil, nil
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(rawSchema))
+	decoder.UseNumber() // Keeps large integers of defaults and enums intact
+	var schema map[string]interface{}
+	if err := decoder.Decode(&schema); err != nil {
+		return "", nil, fmt.Errorf("failed to decode input schema: %w", err)
+	}
+
+	initialSize, err := compactSize(schema)
+	if err != nil {
+		return "", nil, err
+	}
+	if initialSize <= budget {
+		return rawSchema, nil, nil
+	}
+
+	steps := []func(map[string]interface{}) string{
+		dropExamples,
+		func(s map[string]interface{}) string { return truncateDescriptions(s, longDescriptionLength) },
+		func(s map[string]interface{}) string { return truncateDescriptions(s, shortDescriptionLength) },
+		capEnums, // After truncating descriptions, which would cut the accepted values
+	}
+	for depth := schemaDepth(schema, 0) - 1; depth >= 1; depth-- {
+		depth := depth
+		steps = append(steps, func(s map[string]interface{}) string { return collapseNested(s, depth) })
+	}
+
+	var simplifications []string
+	size := initialSize
+	for _, step := range steps {
+		if size <= budget {
+			break
+		}
+		simplification := step(schema)
+		if simplification == "" {
+			continue
+		}
+		// Collapsing again at a lower depth replaces the previous collapse in the report
+		if strings.HasPrefix(simplification, collapsedPrefix) && len(simplifications) > 0 &&
+			strings.HasPrefix(simplifications[len(simplifications)-1], collapsedPrefix) {
+			simplifications = simplifications[:len(simplifications)-1]
+		}
+		simplifications = append(simplifications, simplification)
+		if size, err = compactSize(schema); err != nil {
+			return "", nil, err
+		}
+	}
+
+	if len(simplifications) == 0 {
+		fmt.Printf("Warning: input schema of tool %s is %d bytes, over its %d bytes budget, and cannot be simplified\n", toolName, size, budget)
+		return rawSchema, nil, nil
+	}
+	if size > budget {
+		fmt.Printf("Warning: input schema of tool %s is still %d bytes after simplification, over its %d bytes budget: %s\n",
+			toolName, size, budget, strings.Join(simplifications, ", "))
+	} else {
+		fmt.Printf("Warning: input schema of tool %s simplified from %d to %d bytes to fit its %d bytes budget: %s\n",
+			toolName, initialSize, size, budget, strings.Join(simplifications, ", "))
+	}
+
+	simplified, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to marshal simplified input schema: %w", err)
+	}
+	return string(simplified), simplifications, nil
+}
+
+// compactSize returns the size of a schema encoded as compact JSON
+func compactSize(schema map[string]interface{}) (int, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(schema); err != nil {
+		return 0, fmt.Errorf("failed to measure input schema: %w", err)
+	}
+	return buf.Len() - 1, nil // Without the trailing newline
+}
+
+// walkSchema calls visit on a schema and its sub-schemas, depth first.
+// The depth of the root is given, sub-schemas and $defs entries are one level deeper than their parent.
+// Sub-schemas of a schema are skipped when visit returns false.
+func walkSchema(schema map[string]interface{}, depth int, visit func(schema map[string]interface{}, depth int) bool) {
+	if !visit(schema, depth) {
+		return
+	}
+	for _, keyword := range []string{"properties", definitionsKeyword} {
+		if children, ok := schema[keyword].(map[string]interface{}); ok {
+			for _, name := range sortedMapKeys(children) {
+				if child, ok := children[name].(map[string]interface{}); ok {
+					walkSchema(child, depth+1, visit)
+				}
+			}
+		}
+	}
+	for _, keyword := range []string{"items", "additionalProperties", "not"} {
+		if child, ok := schema[keyword].(map[string]interface{}); ok {
+			walkSchema(child, depth+1, visit)
+		}
+	}
+	for _, keyword := range []string{"oneOf", "anyOf", "allOf"} {
+		if children, ok := schema[keyword].([]interface{}); ok {
+			for _, child := range children {
+				if child, ok := child.(map[string]interface{}); ok {
+					walkSchema(child, depth+1, visit)
+				}
+			}
+		}
+	}
+}
+
+// schemaDepth returns the depth of the most nested sub-schema
+func schemaDepth(schema map[string]interface{}, depth int) int {
+	deepest := depth
+	walkSchema(schema, depth, func(_ map[string]interface{}, d int) bool {
+		if d > deepest {
+			deepest = d
+		}
+		return true
+	})
+	return deepest
+}
+
+// dropExamples removes the examples of every schema
+func dropExamples(schema map[string]interface{}) string {
+	dropped := false
+	walkSchema(schema, 0, func(s map[string]interface{}, _ int) bool {
+		for _, keyword := range []string{"example", "examples"} {
+			if _, ok := s[keyword]; ok {
+				delete(s, keyword)
+				dropped = true
+			}
+		}
+		return true
+	})
+	if !dropped {
+		return ""
+	}
+	return "dropped examples"
+}
+
+// truncateDescriptions shortens the descriptions longer than length characters
+func truncateDescriptions(schema map[string]interface{}, length int) string {
+	truncated := 0
+	walkSchema(schema, 0, func(s map[string]interface{}, _ int) bool {
+		if description, ok := s["description"].(string); ok && utf8.RuneCountInString(description) > length {
+			s["description"] = truncate(description, length)
+			truncated++
+		}
+		return true
+	})
+	if truncated == 0 {
+		return ""
+	}
+	return fmt.Sprintf("truncated %d descriptions to %d characters", truncated, length)
+}
+
+// truncate cuts a text to length characters, the last one being an ellipsis
+func truncate(text string, length int) string {
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+	return strings.TrimSpace(string(runes[:length-1])) + "…"
+}
+
+// capEnums replaces the enum lists longer than maxEnumValues by a description listing the first values
+func capEnums(schema map[string]interface{}) string {
+	capped := 0
+	walkSchema(schema, 0, func(s map[string]interface{}, _ int) bool {
+		values, ok := s["enum"].([]interface{})
+		if !ok || len(values) <= maxEnumValues {
+			return true
+		}
+
+		listed := make([]string, 0, listedEnumValues)
+		for _, value := range values[:listedEnumValues] {
+			encoded, _ := json.Marshal(value)
+			listed = append(listed, string(encoded))
+		}
+		note := fmt.Sprintf("Accepted values include %s and %d more.", strings.Join(listed, ", "), len(values)-listedEnumValues)
+		if description, ok := s["description"].(string); ok && description != "" {
+			note = description + " " + note
+		}
+		s["description"] = note
+		delete(s, "enum")
+		capped++
+		return true
+	})
+	if capped == 0 {
+		return ""
+	}
+	return fmt.Sprintf("replaced %d enum lists longer than %d values by a description", capped, maxEnumValues)
+}
+
+// collapsedPrefix starts the report of collapsed schemas
+const collapsedPrefix = "collapsed schemas nested"
+
+// collapseNested replaces the schemas at depth with sub-schemas by a summary keeping only their type and description
+func collapseNested(schema map[string]interface{}, depth int) string {
+	collapsed := 0
+	walkSchema(schema, 0, func(s map[string]interface{}, d int) bool {
+		if d < depth {
+			return true
+		}
+		summary := schemaSummary(s)
+		if summary == "" {
+			return false
+		}
+
+		description, _ := s["description"].(string)
+		schemaType, hasType := s["type"]
+		for keyword := range s {
+			delete(s, keyword)
+		}
+		if hasType {
+			s["type"] = schemaType
+		}
+		if description != "" {
+			summary = description + " " + summary
+		}
+		s["description"] = summary
+		collapsed++
+		return false
+	})
+	if collapsed == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s %d or more levels deep", collapsedPrefix, depth)
+}
+
+// schemaSummary describes the structure of a schema with sub-schemas, empty for schemas without any
+func schemaSummary(schema map[string]interface{}) string {
+	if properties, ok := schema["properties"].(map[string]interface{}); ok && len(properties) > 0 {
+		return fmt.Sprintf("(object with properties %s, structure omitted)", strings.Join(sortedMapKeys(properties), ", "))
+	}
+	for _, keyword := range []string{"items", "additionalProperties", "not", "oneOf", "anyOf", "allOf"} {
+		switch schema[keyword].(type) {
+		case map[string]interface{}, []interface{}:
+			return "(structure omitted)"
+		}
+	}
+	return ""
+}
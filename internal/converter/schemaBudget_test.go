@@ -0,0 +1,183 @@
+package converter
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const budgetSchema = `{
+  "type": "object",
+  "properties": {
+    "body": {
+      "type": "object",
+      "description": "Order to create",
+      "properties": {
+        "customer": {
+          "type": "object",
+          "properties": {
+            "name": {"type": "string"},
+            "address": {
+              "type": "object",
+              "properties": {"street": {"type": "string"}, "city": {"type": "string"}}
+            }
+          }
+        },
+        "country": {
+          "type": "string",
+          "enum": ["AR", "AT", "AU", "BE", "BR", "CA", "CH", "CN", "DE", "DK", "DZ", "ES", "FI", "FR", "GB", "IE", "IN", "IT", "JP", "MX", "NL", "NO", "SE", "US"]
+        }
+      },
+      "example": {"customer": {"name": "Ada"}, "country": "FR"}
+    },
+    "note": {
+      "type": "string",
+      "description": "` + longText + `"
+    }
+  },
+  "required": ["body"]
+}`
+
+const longText = "A free text note attached to the order, printed on the delivery slip and shown to the customer in every notification sent about the order, so it should be short, polite and free of personal data such as phone numbers or email addresses."
+
+func TestConverter_fitSchemaBudget(t *testing.T) {
+	initialSize := len(compactJSON(t, budgetSchema))
+
+	tests := []struct {
+		name                string
+		budget              SchemaBudget
+		wantSimplifications []string
+		check               func(t *testing.T, schema map[string]interface{})
+	}{
+		{
+			name:   "no budget",
+			budget: SchemaBudget{},
+		},
+		{
+			name:   "within budget",
+			budget: SchemaBudget{MaxBytes: initialSize},
+		},
+		{
+			name:   "budget of another tool",
+			budget: SchemaBudget{MaxBytes: 1, Tools: map[string]int{"createOrder": initialSize}},
+		},
+		{
+			name:                "drop examples",
+			budget:              SchemaBudget{MaxBytes: initialSize - 10},
+			wantSimplifications: []string{"dropped examples"},
+			check: func(t *testing.T, schema map[string]interface{}) {
+				if _, ok := property(schema, "body")["example"]; ok {
+					t.Error("example of body kept")
+				}
+			},
+		},
+		{
+			name:   "cap enums",
+			budget: SchemaBudget{MaxBytes: 550},
+			wantSimplifications: []string{
+				"dropped examples",
+				"truncated 1 descriptions to 200 characters",
+				"truncated 1 descriptions to 80 characters",
+				"replaced 1 enum lists longer than 10 values by a description",
+			},
+			check: func(t *testing.T, schema map[string]interface{}) {
+				country := property(property(schema, "body"), "country")
+				if _, ok := country["enum"]; ok {
+					t.Error("enum of country kept")
+				}
+				want := `Accepted values include "AR", "AT", "AU", "BE", "BR" and 19 more.`
+				if country["description"] != want {
+					t.Errorf("country description = %q, want %q", country["description"], want)
+				}
+			},
+		},
+		{
+			name:   "collapse nesting",
+			budget: SchemaBudget{Tools: map[string]int{"createOrder": 300}},
+			wantSimplifications: []string{
+				"dropped examples",
+				"truncated 1 descriptions to 200 characters",
+				"truncated 1 descriptions to 80 characters",
+				"replaced 1 enum lists longer than 10 values by a description",
+				"collapsed schemas nested 1 or more levels deep",
+			},
+			check: func(t *testing.T, schema map[string]interface{}) {
+				want := map[string]interface{}{
+					"type":        "object",
+					"description": "Order to create (object with properties country, customer, structure omitted)",
+				}
+				if body := property(schema, "body"); !reflect.DeepEqual(body, want) {
+					t.Errorf("body = %v, want %v", body, want)
+				}
+				if note := property(schema, "note")["description"].(string); len([]rune(note)) > 80 || !strings.HasSuffix(note, "…") {
+					t.Errorf("note description = %q, want at most 80 characters ending with an ellipsis", note)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Converter{}
+			if err := c.SetSchemaBudget(tt.budget); err != nil {
+				t.Fatalf("SetSchemaBudget() error = %v", err)
+			}
+
+			raw, simplifications, err := c.fitSchemaBudget("createOrder", budgetSchema)
+			if err != nil {
+				t.Fatalf("fitSchemaBudget() error = %v", err)
+			}
+			if !reflect.DeepEqual(simplifications, tt.wantSimplifications) {
+				t.Errorf("simplifications = %q, want %q", simplifications, tt.wantSimplifications)
+			}
+			if tt.check == nil {
+				if raw != budgetSchema {
+					t.Errorf("schema changed within budget:\n%s", raw)
+				}
+				return
+			}
+
+			budget := c.budgetOf("createOrder")
+			if size := len(compactJSON(t, raw)); size > budget {
+				t.Errorf("schema is %d bytes, over the %d bytes budget", size, budget)
+			}
+			var schema map[string]interface{}
+			if err := json.Unmarshal([]byte(raw), &schema); err != nil {
+				t.Fatalf("invalid simplified schema: %v", err)
+			}
+			tt.check(t, schema)
+		})
+	}
+}
+
+func TestConverter_SetSchemaBudget(t *testing.T) {
+	c := &Converter{}
+	if err := c.SetSchemaBudget(SchemaBudget{MaxBytes: -1}); err == nil {
+		t.Error("SetSchemaBudget() error = nil, want error for a negative budget")
+	}
+	if err := c.SetSchemaBudget(SchemaBudget{Tools: map[string]int{"createOrder": -1}}); err == nil {
+		t.Error("SetSchemaBudget() error = nil, want error for a negative tool budget")
+	}
+}
+
+// compactJSON removes the insignificant whitespace of a JSON document
+func compactJSON(t *testing.T, raw string) string {
+	t.Helper()
+	var value interface{}
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	compact, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("failed to marshal JSON: %v", err)
+	}
+	return string(compact)
+}
+
+// property returns a property schema of an object schema
+func property(schema map[string]interface{}, name string) map[string]interface{} {
+	properties, _ := schema["properties"].(map[string]interface{})
+	prop, _ := properties[name].(map[string]interface{})
+	return prop
+}
@@ -8,9 +8,18 @@ import (
 // GenerateJSONSchemaDraft7 converts a slice of Arg structs into a JSON Schema Draft 7 string.
 // It creates a root object schema with properties for each argument.
 func GenerateJSONSchemaDraft7(args []Arg) (string, error) {
+	return generateInputSchema(args, nil, nil)
+}
+
+// generateInputSchema builds the input schema of a tool, examples are complete sets of arguments.
+// The definitions referenced by the argument schemas are added under $defs.
+func generateInputSchema(args []Arg, examples []interface{}, definitions map[string]*Schema) (string, error) {
 	rootSchema := map[string]interface{}{
 		"type": "object",
 	}
+	if len(examples) > 0 {
+		rootSchema["examples"] = examples
+	}
 
 	properties := make(map[string]interface{})
 	requiredProperties := []string{}
@@ -37,6 +46,9 @@ func GenerateJSONSchemaDraft7(args []Arg) (string, error) {
 	if len(requiredProperties) > 0 {
 		rootSchema["required"] = requiredProperties
 	}
+	if err := addDefinitions(rootSchema, definitions); err != nil {
+		return "", err
+	}
 
 	schemaBytes, err := json.MarshalIndent(rootSchema, "", "  ")
 	if err != nil {
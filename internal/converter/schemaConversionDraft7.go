@@ -8,6 +8,16 @@ func schemaToDraft7Map(s *Schema) (map[string]interface{}, error) {
 	}
 
 	result := make(map[string]interface{})
+	if s.Ref != "" {
+		result["$ref"] = "#/" + definitionsKeyword + "/" + escapePointer(s.Ref)
+		if s.Description != "" {
+			result["description"] = s.Description
+		}
+		return result, nil
+	}
+	if isFileSchema(s) {
+		return fileSchemaToDraft7Map(s), nil
+	}
 
 	addBasicMetadata(result, s)
 	addType(result, s)
@@ -43,6 +53,9 @@ func addBasicMetadata(result map[string]interface{}, s *Schema) {
 	if s.Example != nil {
 		result["example"] = s.Example
 	}
+	if len(s.Examples) > 0 {
+		result["examples"] = s.Examples
+	}
 	if len(s.Enum) > 0 {
 		result["enum"] = s.Enum
 	}
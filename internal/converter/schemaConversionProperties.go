@@ -1,6 +1,9 @@
 package converter
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+)
 
 // buildPropertySchema builds the JSON Schema property for a given Arg.
 // Returns nil if the property should be skipped.
@@ -8,8 +11,8 @@ func buildPropertySchema(arg Arg) (map[string]interface{}, error) {
 	var propSchema map[string]interface{}
 	var err error
 
-	switch arg.Source {
-	case "body":
+	switch {
+	case arg.Source == "body" && arg.BodyProperty == "":
 		propSchema, err = buildBodySchema(arg)
 	default:
 		if arg.Schema == nil {
@@ -44,9 +47,16 @@ func buildBodySchema(arg Arg) (map[string]interface{}, error) {
 		}
 	}
 
-	// Multiple content types: use oneOf
-	oneOfSchemas := []map[string]interface{}{}
-	for contentType, schema := range arg.ContentTypes {
+	// Multiple content types: use anyOf, the same body often matches several content types.
+	// Branches are sorted so that generation is deterministic.
+	anyOfSchemas := []map[string]interface{}{}
+	contentTypes := make([]string, 0, len(arg.ContentTypes))
+	for contentType := range arg.ContentTypes {
+		contentTypes = append(contentTypes, contentType)
+	}
+	sort.Strings(contentTypes)
+	for _, contentType := range contentTypes {
+		schema := arg.ContentTypes[contentType]
 		branchSchema, err := schemaToDraft7Map(schema)
 		if err != nil {
 			return nil, fmt.Errorf(
@@ -57,14 +67,14 @@ func buildBodySchema(arg Arg) (map[string]interface{}, error) {
 		if branchSchema != nil {
 			// Add content type info to title/description
 			addContentTypeInfo(branchSchema, contentType)
-			oneOfSchemas = append(oneOfSchemas, branchSchema)
+			anyOfSchemas = append(anyOfSchemas, branchSchema)
 		}
 	}
-	if len(oneOfSchemas) == 0 {
+	if len(anyOfSchemas) == 0 {
 		return nil, nil
 	}
 	return map[string]interface{}{
-		"oneOf": oneOfSchemas,
+		"anyOf": anyOfSchemas,
 	}, nil
 }
 
@@ -98,13 +98,13 @@ func TestBuildPropertySchema(t *testing.T) {
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
-		oneOf, ok := got["oneOf"].([]map[string]interface{})
-		if !ok || len(oneOf) != 2 {
-			t.Fatalf("expected oneOf with 2 schemas, got %v", got["oneOf"])
+		anyOf, ok := got["anyOf"].([]map[string]interface{})
+		if !ok || len(anyOf) != 2 {
+			t.Fatalf("expected anyOf with 2 schemas, got %v", got["anyOf"])
 		}
 		// Check content type info is added
 		found := false
-		for _, sch := range oneOf {
+		for _, sch := range anyOf {
 			if title, ok := sch["title"].(string); ok && title == "[application/xml] Other" {
 				found = true
 			}
@@ -175,12 +175,12 @@ func TestBuildBodySchema(t *testing.T) {
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
-		oneOf, ok := got["oneOf"].([]map[string]interface{})
-		if !ok || len(oneOf) != 2 {
-			t.Fatalf("expected oneOf with 2 schemas, got %v", got["oneOf"])
+		anyOf, ok := got["anyOf"].([]map[string]interface{})
+		if !ok || len(anyOf) != 2 {
+			t.Fatalf("expected anyOf with 2 schemas, got %v", got["anyOf"])
 		}
 		found := false
-		for _, sch := range oneOf {
+		for _, sch := range anyOf {
 			if title, ok := sch["title"].(string); ok && title == "[application/xml] Other" {
 				found = true
 			}
@@ -199,12 +199,12 @@ func TestBuildBodySchema(t *testing.T) {
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
-		oneOf, ok := got["oneOf"].([]map[string]interface{})
-		if !ok || len(oneOf) != 1 {
-			t.Fatalf("expected oneOf with 1 schema, got %v", got["oneOf"])
+		anyOf, ok := got["anyOf"].([]map[string]interface{})
+		if !ok || len(anyOf) != 1 {
+			t.Fatalf("expected anyOf with 1 schema, got %v", got["anyOf"])
 		}
-		if oneOf[0]["title"] != "[application/json] Test" {
-			t.Errorf("title = %v, want [application/json] Test", oneOf[0]["title"])
+		if anyOf[0]["title"] != "[application/json] Test" {
+			t.Errorf("title = %v, want [application/json] Test", anyOf[0]["title"])
 		}
 	})
 }
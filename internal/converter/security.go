@@ -0,0 +1,79 @@
+package converter
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// defaultCredentialExtension lets a spec provide a fallback credential for a security scheme
+const defaultCredentialExtension = "x-default-credential"
+
+// convertSecuritySchemes converts the components.securitySchemes of the document, sorted by ID
+func (c *Converter) convertSecuritySchemes() []SecurityScheme {
+	doc := c.parser.GetDocument()
+	if doc == nil || doc.Components == nil {
+		return nil
+	}
+
+	var schemes []SecurityScheme
+	for id, schemeRef := range doc.Components.SecuritySchemes {
+		if schemeRef == nil || schemeRef.Value == nil {
+			continue
+		}
+		scheme := schemeRef.Value
+
+		converted := SecurityScheme{
+			ID:     id,
+			Type:   scheme.Type,
+			Scheme: scheme.Scheme,
+			In:     scheme.In,
+			Name:   scheme.Name,
+		}
+		if credential, ok := scheme.Extensions[defaultCredentialExtension].(string); ok {
+			converted.DefaultCredential = credential
+		}
+		schemes = append(schemes, converted)
+	}
+
+	sort.Slice(schemes, func(i, j int) bool {
+		return schemes[i].ID < schemes[j].ID
+	})
+
+	return schemes
+}
+
+// createSecurityRequirements lists the security schemes an operation can use, by alternative.
+// The operation requirements override the global ones; an empty list disables security.
+// Every alternative is kept in order, the first one whose credentials are all configured is applied at runtime.
+// Empty alternatives, making security optional, are left out: nothing is sent when no alternative is configured.
+// A scheme missing from components.securitySchemes is an error, as its alternative could never be fully applied.
+func (c *Converter) createSecurityRequirements(operation *openapi3.Operation) ([]ToolSecurityRequirement, error) {
+	doc := c.parser.GetDocument()
+	requirements := doc.Security
+	if operation.Security != nil {
+		requirements = *operation.Security
+	}
+
+	var security []ToolSecurityRequirement
+	alternative := 0
+	for _, requirement := range requirements {
+		if len(requirement) == 0 {
+			continue
+		}
+		ids := make([]string, 0, len(requirement))
+		for id := range requirement {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			if doc.Components == nil || doc.Components.SecuritySchemes[id] == nil {
+				return nil, fmt.Errorf("security requirement references unknown security scheme %q", id)
+			}
+			security = append(security, ToolSecurityRequirement{ID: id, Alternative: alternative})
+		}
+		alternative++
+	}
+	return security, nil
+}
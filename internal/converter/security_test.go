@@ -0,0 +1,131 @@
+package converter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestConvertSecuritySchemes(t *testing.T) {
+	doc := &openapi3.T{
+		Components: &openapi3.Components{
+			SecuritySchemes: openapi3.SecuritySchemes{
+				"bearerAuth": &openapi3.SecuritySchemeRef{
+					Value: &openapi3.SecurityScheme{Type: "http", Scheme: "bearer"},
+				},
+				"ApiKeyAuth": &openapi3.SecuritySchemeRef{
+					Value: &openapi3.SecurityScheme{
+						Type:       "apiKey",
+						In:         "query",
+						Name:       "api_key",
+						Extensions: map[string]any{"x-default-credential": "demo"},
+					},
+				},
+				"broken": &openapi3.SecuritySchemeRef{},
+			},
+		},
+	}
+	c := &Converter{parser: &Parser{doc: doc}}
+
+	got := c.convertSecuritySchemes()
+	want := []SecurityScheme{
+		{ID: "ApiKeyAuth", Type: "apiKey", In: "query", Name: "api_key", DefaultCredential: "demo"},
+		{ID: "bearerAuth", Type: "http", Scheme: "bearer"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("convertSecuritySchemes() = %+v, want %+v", got, want)
+	}
+}
+
+func TestConvertSecuritySchemes_NoComponents(t *testing.T) {
+	c := &Converter{parser: &Parser{doc: &openapi3.T{}}}
+	if got := c.convertSecuritySchemes(); got != nil {
+		t.Errorf("expected no security schemes, got %+v", got)
+	}
+}
+
+func TestCreateSecurityRequirements(t *testing.T) {
+	scheme := &openapi3.SecuritySchemeRef{Value: &openapi3.SecurityScheme{Type: "http", Scheme: "bearer"}}
+	doc := &openapi3.T{
+		Security: openapi3.SecurityRequirements{
+			{"ApiKeyAuth": []string{}},
+		},
+		Components: &openapi3.Components{
+			SecuritySchemes: openapi3.SecuritySchemes{"ApiKeyAuth": scheme, "basicAuth": scheme, "oauth": scheme},
+		},
+	}
+	c := &Converter{parser: &Parser{doc: doc}}
+
+	tests := []struct {
+		name     string
+		security *openapi3.SecurityRequirements
+		want     []ToolSecurityRequirement
+		wantErr  string
+	}{
+		{
+			name: "inherits global requirements",
+			want: []ToolSecurityRequirement{{ID: "ApiKeyAuth"}},
+		},
+		{
+			name:     "operation overrides with alternatives",
+			security: &openapi3.SecurityRequirements{{"oauth": []string{"read"}}, {"basicAuth": []string{}, "ApiKeyAuth": []string{}}},
+			want:     []ToolSecurityRequirement{{ID: "oauth"}, {ID: "ApiKeyAuth", Alternative: 1}, {ID: "basicAuth", Alternative: 1}},
+		},
+		{
+			name:     "empty alternative makes security optional",
+			security: &openapi3.SecurityRequirements{{}, {"ApiKeyAuth": []string{}}},
+			want:     []ToolSecurityRequirement{{ID: "ApiKeyAuth"}},
+		},
+		{
+			name:     "empty operation requirements disable security",
+			security: &openapi3.SecurityRequirements{},
+			want:     nil,
+		},
+		{
+			name:     "unknown scheme",
+			security: &openapi3.SecurityRequirements{{"ApiKeyAuth": []string{}, "tokenAuth": []string{}}},
+			wantErr:  `unknown security scheme "tokenAuth"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.createSecurityRequirements(&openapi3.Operation{Security: tt.security})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("createSecurityRequirements() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("createSecurityRequirements() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("createSecurityRequirements() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConverter_Convert_SecuritySchemes(t *testing.T) {
+	parser := NewParser(false)
+	if err := parser.ParseFile("../../testdata/todoopenapi.yaml"); err != nil {
+		t.Fatalf("failed to parse OpenAPI: %v", err)
+	}
+
+	config, err := NewConverter(parser).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	if len(config.Server.SecuritySchemes) != 1 || config.Server.SecuritySchemes[0].ID != "ApiKeyAuth" {
+		t.Fatalf("expected the ApiKeyAuth security scheme, got %+v", config.Server.SecuritySchemes)
+	}
+	for _, tool := range config.Tools {
+		if !reflect.DeepEqual(tool.RequestTemplate.Security, []ToolSecurityRequirement{{ID: "ApiKeyAuth"}}) {
+			t.Errorf("tool %s: expected global ApiKeyAuth requirement, got %+v", tool.Name, tool.RequestTemplate.Security)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/yaml"
+)
+
+// isSwagger2 reports whether a document declares swagger: "2.0"
+func isSwagger2(data []byte) bool {
+	var header struct {
+		Swagger string `json:"swagger"`
+	}
+	if err := yaml.Unmarshal(data, &header); err != nil {
+		return false
+	}
+	return header.Swagger == "2.0"
+}
+
+// convertSwagger2 upgrades a Swagger 2.0 document to OpenAPI 3.0 and loads it.
+// formData parameters become form request bodies, consumes and produces become
+// request and response content types and securityDefinitions become security schemes.
+func convertSwagger2(loader *openapi3.Loader, data []byte, location *url.URL) (*openapi3.T, error) {
+	var doc2 openapi2.T
+	if err := yaml.Unmarshal(data, &doc2); err != nil {
+		return nil, fmt.Errorf("failed to read Swagger 2.0 document: %w", err)
+	}
+
+	// The upgrade only looks at operation level produces, apply the global ones first
+	if len(doc2.Produces) > 0 {
+		for _, pathItem := range doc2.Paths {
+			if pathItem == nil {
+				continue
+			}
+			for _, operation := range pathItem.Operations() {
+				if len(operation.Produces) == 0 {
+					operation.Produces = doc2.Produces
+				}
+			}
+		}
+	}
+
+	doc3, err := openapi2conv.ToV3WithLoader(&doc2, loader, location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert Swagger 2.0 document to OpenAPI 3.0: %w", err)
+	}
+	requireFormBodies(doc3)
+
+	// Reload the upgraded document so it is shaped exactly like a native 3.0 one,
+	// the upgrade leaves empty allOf lists that code generation does not expect
+	data, err = json.Marshal(doc3)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write converted Swagger 2.0 document: %w", err)
+	}
+	if location != nil {
+		return loader.LoadFromDataWithPath(data, location)
+	}
+	return loader.LoadFromData(data)
+}
+
+// requireFormBodies marks the request bodies built from formData parameters as required
+// when one of the parameters was, the upgrade leaves them optional
+func requireFormBodies(doc *openapi3.T) {
+	if doc.Paths == nil {
+		return
+	}
+	for _, pathItem := range doc.Paths.Map() {
+		for _, operation := range pathItem.Operations() {
+			if operation.RequestBody == nil || operation.RequestBody.Ref != "" || operation.RequestBody.Value == nil {
+				continue
+			}
+			body := operation.RequestBody.Value
+			for contentType, mediaType := range body.Content {
+				if contentType != "application/x-www-form-urlencoded" && contentType != "multipart/form-data" {
+					continue
+				}
+				if mediaType.Schema != nil && mediaType.Schema.Value != nil && len(mediaType.Schema.Value.Required) > 0 {
+					body.Required = true
+				}
+			}
+		}
+	}
+}
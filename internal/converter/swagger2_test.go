@@ -0,0 +1,114 @@
+package converter
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var swagger2SpecPath = filepath.Join("..", "..", "testdata", "swagger2.yaml")
+
+func TestIsSwagger2(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want bool
+	}{
+		{"swagger 2.0 yaml", "swagger: \"2.0\"\ninfo:\n  title: T\n", true},
+		{"swagger 2.0 json", `{"swagger": "2.0"}`, true},
+		{"openapi 3.0", "openapi: 3.0.3\n", false},
+		{"invalid", "[", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isSwagger2([]byte(tt.data)); got != tt.want {
+				t.Errorf("isSwagger2() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParser_ParseFile_Swagger2(t *testing.T) {
+	p := NewParser(true)
+	if err := p.ParseFile(swagger2SpecPath); err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	config, err := NewConverter(p).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	wantSchemes := []SecurityScheme{
+		{ID: "apiKey", Type: "apiKey", In: "header", Name: "X-API-Key"},
+		{ID: "basicAuth", Type: "http", Scheme: "basic"},
+	}
+	if !reflect.DeepEqual(config.Server.SecuritySchemes, wantSchemes) {
+		t.Errorf("SecuritySchemes = %+v, want %+v", config.Server.SecuritySchemes, wantSchemes)
+	}
+
+	tools := make(map[string]Tool)
+	for _, tool := range config.Tools {
+		tools[tool.Name] = tool
+	}
+
+	tests := []struct {
+		tool         string
+		url          string
+		contentType  string
+		security     string
+		responseType string
+	}{
+		{"createPet", "https://petstore.example.com/v1/pets", "application/json", "apiKey", "application/json"},
+		{"getPet", "https://petstore.example.com/v1/pets/{petId}", "", "apiKey", "application/xml"},
+		{"updatePet", "https://petstore.example.com/v1/pets/{petId}", "application/x-www-form-urlencoded", "basicAuth", "application/json"},
+		{"uploadPhoto", "https://petstore.example.com/v1/pets/{petId}/photo", "multipart/form-data", "apiKey", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			tool, ok := tools[tt.tool]
+			if !ok {
+				t.Fatalf("expected tool %s", tt.tool)
+			}
+			if tool.RequestTemplate.URL != tt.url {
+				t.Errorf("URL = %s, want %s", tool.RequestTemplate.URL, tt.url)
+			}
+
+			contentType := ""
+			for _, header := range tool.RequestTemplate.Headers {
+				if header.Key == "Content-Type" {
+					contentType = header.Value
+				}
+			}
+			if contentType != tt.contentType {
+				t.Errorf("Content-Type = %q, want %q", contentType, tt.contentType)
+			}
+
+			if len(tool.RequestTemplate.Security) != 1 || tool.RequestTemplate.Security[0].ID != tt.security {
+				t.Errorf("Security = %+v, want %s", tool.RequestTemplate.Security, tt.security)
+			}
+
+			if tt.responseType != "" && (len(tool.Responses) == 0 || tool.Responses[0].ContentType != tt.responseType) {
+				t.Errorf("Responses = %+v, want content type %s", tool.Responses, tt.responseType)
+			}
+		})
+	}
+
+	// formData parameters become a required form body holding every field
+	var body *Arg
+	for i, arg := range tools["uploadPhoto"].Args {
+		if arg.Source == "body" {
+			body = &tools["uploadPhoto"].Args[i]
+		}
+	}
+	if body == nil || !body.Required {
+		t.Fatalf("expected a required body argument, got %+v", tools["uploadPhoto"].Args)
+	}
+	schema := body.ContentTypes["multipart/form-data"]
+	if schema == nil || schema.Object == nil || schema.Object.Properties["file"] == nil || schema.Object.Properties["caption"] == nil {
+		t.Fatalf("expected file and caption form fields, got %+v", body.ContentTypes)
+	}
+	if schema.Object.Properties["file"].Format != "binary" {
+		t.Errorf("file format = %q, want binary", schema.Object.Properties["file"].Format)
+	}
+}
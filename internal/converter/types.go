@@ -1,9 +1,12 @@
 package converter
 
+import "time"
+
 // MCPConfig represents the top-level MCP server configuration
 type MCPConfig struct {
-	Server ServerConfig
-	Tools  []Tool
+	Server    ServerConfig
+	Tools     []Tool
+	Resources []Resource
 }
 
 // ServerConfig represents the MCP server configuration
@@ -25,28 +28,103 @@ type SecurityScheme struct {
 // Tool represents an MCP tool configuration
 type Tool struct {
 	Name            string
+	MCPName         string // Name exposed to clients from x-mcp-name, derived from Name when empty
 	Description     string
+	Tags            []string
 	Args            []Arg
+	HiddenArgs      []Arg // Arguments hidden from clients through x-mcp-hidden-params or x-mcp-hidden
+	Annotations     ToolAnnotations
 	RequestTemplate RequestTemplate
 	Responses       []ResponseTemplate
 	RawInputSchema  string
+	Definitions     map[string]*Schema // Component schemas referenced by the argument schemas, by name
+	Output          *ToolOutput        // Structured output of the primary success response, nil when it has no JSON body
+	Errors          map[string]string  // Documented meaning of the error responses by status code, range such as 4XX, or default
+	Policy          RequestPolicy      // Timeout and retries of the upstream request from x-mcp-timeout and x-mcp-retry
+	Pagination      *Pagination        // Pagination of list operations, nil when the operation returns a single page
+
+	SchemaSimplifications []string // Simplifications applied to RawInputSchema to fit the schema budget
+}
+
+// ToolOutput describes the structured content returned for the primary success response of a tool
+type ToolOutput struct {
+	StatusCode  int
+	ContentType string
+	Property    string // Property wrapping the response body in the structured content, empty when the body is an object
+	RawSchema   string
+}
+
+// Pagination describes how a list operation returns its items over several pages
+type Pagination struct {
+	Style  string // One of PaginationPage, PaginationOffset, PaginationCursor or PaginationLink
+	Param  string // Query parameter carrying the page number, offset or cursor, empty when the cursor is a URL
+	Start  int    // Number of the first page for the page style, from the default or minimum of its parameter
+	Cursor string // Dot path of the next cursor, or next page URL, in the response body for the cursor style
+	Items  string // Dot path of the items array in the response body, empty when the body is the array
+}
+
+// RequestPolicy bounds the upstream requests of a tool, zero values fall back to the defaults of the generated server
+type RequestPolicy struct {
+	Timeout        time.Duration // Bounds the whole call, retries included
+	MaxAttempts    int           // Attempts including the first one, 1 disables retries
+	InitialBackoff time.Duration // Delay before the first retry, doubled on each retry
+	MaxBackoff     time.Duration // Upper bound of the delay between two attempts
+}
+
+// ToolAnnotations describes the behavior of a tool to MCP clients, nil hints are left to the client defaults
+type ToolAnnotations struct {
+	Title           string
+	ReadOnlyHint    *bool
+	DestructiveHint *bool
+	IdempotentHint  *bool
+	OpenWorldHint   *bool
+}
+
+// Resource represents an MCP resource, or a resource template when its URI has variables
+type Resource struct {
+	Name        string // operationId of the GET operation or name of the component schema
+	URI         string // URI, or RFC 6570 URI template when Template is set
+	Template    bool
+	Description string
+	MIMEType    string
+	Text        string // Content of static resources
+	Tool        *Tool  // Operation read by the resource, nil for static resources
 }
 
 // RequestTemplate represents the MCP request template
 type RequestTemplate struct {
 	URL            string
+	Path           string // Path template relative to the server URL, e.g. "/todos/{todoId}"
 	Method         string
 	Headers        []Header
 	Body           string
 	ArgsToJsonBody bool
 	ArgsToUrlParam bool
-	ArgsToFormBody bool
+	ArgsToFormBody bool                    // The default body content type is form-urlencoded or multipart
+	ContentTypes   []string                // Content types of the request body by preference, the first one is sent by default
+	BodyRequired   bool                    // The request body is required, even when none of its properties is
+	BodyEncodings  map[string]BodyEncoding // How the body is sent with the content types needing more than the media type
 	Security       []ToolSecurityRequirement
 }
 
+// BodyEncoding describes how the request body is sent with a content type
+type BodyEncoding struct {
+	Fields map[string]FieldEncoding // Encoding of the form body properties that differ from the defaults
+	Binary bool                     // The body is file content sent as is
+}
+
+// FieldEncoding describes how a property of a form-urlencoded or multipart body is sent
+type FieldEncoding struct {
+	ContentType string // Content type of the multipart part, from the encoding object
+	Binary      bool   // File content, from format: binary
+	Style       string // Serialization of form-urlencoded properties with an encoding object, the default encoding when empty
+	Explode     bool
+}
+
 // ToolSecurityRequirement specifies a security scheme requirement for a tool.
 type ToolSecurityRequirement struct {
-	ID string
+	ID          string
+	Alternative int // Index of the security requirement alternative, the schemes of an alternative are used together
 }
 
 // Header represents an HTTP header
@@ -58,9 +136,10 @@ type Header struct {
 // ResponseTemplate represents the MCP response template
 type ResponseTemplate struct {
 	PrependBody string
-	StatusCode  int
+	StatusCode  int    // 0 for ranges and default
+	Code        string // Documented status code, range such as 2XX, or default
 	ContentType string
-	Suffix       string 
+	Suffix      string
 }
 
 // ConvertOptions represents options for the conversion process
@@ -84,16 +163,24 @@ type MCPConfigTemplate struct {
 type Arg struct {
 	Name        string  `json:"name"`
 	Description string  `json:"description,omitempty"`
-	Source      string  `json:"source"` // "path", "query", "header", "body"
+	Source      string  `json:"source"` // "path", "query", "header", "cookie", "body", "pagination"
 	Required    bool    `json:"required"`
 	Deprecated  bool    `json:"deprecated,omitempty"`
 	Schema      *Schema `json:"schema"`
+	// Name of the parameter in the HTTP request, Name being sanitized and unique among the tool arguments
+	ParamName string `json:"paramName,omitempty"`
+	// OpenAPI serialization style and explode of parameters, with the defaults of their location applied
+	Style   string `json:"style,omitempty"`
+	Explode bool   `json:"explode,omitempty"`
+	// Property of the request body object filled by a flattened body argument, empty for the whole body
+	BodyProperty string `json:"bodyProperty,omitempty"`
 	// For request bodies with multiple content types
 	ContentTypes map[string]*Schema `json:"contentTypes,omitempty"`
 }
 
 // Schema represents the structure and validation rules for data
 type Schema struct {
+	Ref         string            `json:"$ref,omitempty"` // Name of the referenced component schema, the other fields are then empty
 	Types       []string          `json:"types"`
 	OneOf       []*Schema         `json:"oneOf,omitempty"`
 	AnyOf       []*Schema         `json:"anyOf,omitempty"`
@@ -104,6 +191,7 @@ type Schema struct {
 	Format      string            `json:"format,omitempty"`
 	Default     interface{}       `json:"default,omitempty"`
 	Example     interface{}       `json:"example,omitempty"`
+	Examples    []interface{}     `json:"examples,omitempty"`
 	Enum        []interface{}     `json:"enum,omitempty"`
 	ReadOnly    bool              `json:"readOnly,omitempty"`
 	WriteOnly   bool              `json:"writeOnly,omitempty"`
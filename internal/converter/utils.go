@@ -124,3 +124,14 @@ func contains(slice []string, str string) bool {
 	}
 	return false
 }
+
+// removeString returns a copy of the slice without str.
+func removeString(slice []string, str string) []string {
+	var result []string
+	for _, s := range slice {
+		if s != str {
+			result = append(result, s)
+		}
+	}
+	return result
+}
@@ -0,0 +1,460 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/lyeslabs/mcpgen/internal/converter"
+)
+
+// structField is a field of a generated struct
+type structField struct {
+	JSONName    string
+	Description string
+	Schema      *converter.Schema
+	Required    bool
+
+	Location     string // Where a renamed argument goes in the request, e.g. `query parameter "filter[status]"`
+	BodyProperty string // Request body property of a flattened body argument
+	GoName       string // Set when the struct is declared
+	GoType       string
+}
+
+// argsTypeBuilder generates the Go declarations of the typed arguments of a tool
+type argsTypeBuilder struct {
+	toolNameGo  string
+	decls       []string
+	used        map[string]bool              // Declared type and constant names
+	definitions map[string]*converter.Schema // Referenced component schemas
+	refs        map[string]string            // Go types of the converted references
+	resolving   map[string]string            // Names of the struct types of the references being converted
+}
+
+// argsTypes returns the declarations of the <Tool>Args struct with its nested types and enum constants.
+// Optional arguments are pointers, schemas without a single Go equivalent become any.
+// Referenced component schemas are declared once per tool and named after the component.
+func argsTypes(toolNameGo string, args []converter.Arg, definitions map[string]*converter.Schema) string {
+	b := &argsTypeBuilder{
+		toolNameGo:  toolNameGo,
+		used:        make(map[string]bool),
+		definitions: definitions,
+		refs:        make(map[string]string),
+		resolving:   make(map[string]string),
+	}
+
+	fields := make([]structField, 0, len(args))
+	for _, arg := range args {
+		fields = append(fields, structField{
+			JSONName:     arg.Name,
+			Description:  arg.Description,
+			Schema:       argSchema(arg),
+			Required:     arg.Required,
+			Location:     argLocation(arg),
+			BodyProperty: arg.BodyProperty,
+		})
+	}
+
+	name := b.uniqueName(toolNameGo + "Args")
+	b.declareStruct(name, fmt.Sprintf("%s holds the arguments of the %s tool", name, toolNameGo), fields)
+	b.declareBodyMethod(name, fields)
+	return strings.Join(b.decls, "\n")
+}
+
+// declareBodyMethod declares the RequestBody method rebuilding the request body from the flattened body arguments.
+// It is left out when a field has the name of the method.
+func (b *argsTypeBuilder) declareBodyMethod(name string, fields []structField) {
+	var body strings.Builder
+	for _, field := range fields {
+		if field.GoName == "RequestBody" {
+			return
+		}
+		if field.BodyProperty == "" {
+			continue
+		}
+		if isNilable(field.GoType) {
+			fmt.Fprintf(&body, "\tif a.%s != nil {\n\t\tbody[%q] = a.%s\n\t}\n", field.GoName, field.BodyProperty, field.GoName)
+		} else {
+			fmt.Fprintf(&body, "\tbody[%q] = a.%s\n", field.BodyProperty, field.GoName)
+		}
+	}
+	if body.Len() == 0 {
+		return
+	}
+	b.decls = append(b.decls, fmt.Sprintf("// RequestBody rebuilds the request body from the arguments flattened out of it\nfunc (a *%s) RequestBody() map[string]any {\n\tbody := make(map[string]any)\n%s\treturn body\n}\n", name, body.String()))
+}
+
+// argLocation describes where an argument goes in the request when its name differs from the parameter name
+func argLocation(arg converter.Arg) string {
+	if arg.BodyProperty != "" && arg.BodyProperty != arg.Name {
+		return fmt.Sprintf("body property %q", arg.BodyProperty)
+	}
+	if arg.ParamName != "" && arg.ParamName != arg.Name {
+		return fmt.Sprintf("%s parameter %q", arg.Source, arg.ParamName)
+	}
+	return ""
+}
+
+// argSchema returns the schema of an argument, request bodies with several content types have none
+func argSchema(arg converter.Arg) *converter.Schema {
+	if arg.Schema != nil {
+		return arg.Schema
+	}
+	if len(arg.ContentTypes) == 1 {
+		for _, schema := range arg.ContentTypes {
+			return schema
+		}
+	}
+	return nil
+}
+
+// declareStruct declares a struct type, before the nested types of its fields
+// The Go name and type of the fields are set.
+func (b *argsTypeBuilder) declareStruct(name, comment string, fields []structField) {
+	index := len(b.decls)
+	b.decls = append(b.decls, "")
+
+	fieldNames := make(map[string]bool)
+	var body strings.Builder
+	for i, field := range fields {
+		fieldName := uniqueIdentifier(goIdentifier(field.JSONName), fieldNames)
+		goType := b.goType(name+fieldName, field.Schema)
+		if !field.Required || isNullable(b.resolve(field.Schema)) {
+			goType = optionalType(goType)
+		}
+		fields[i].GoName, fields[i].GoType = fieldName, goType
+
+		description := field.Description
+		if description == "" && field.Schema != nil {
+			description = field.Schema.Description
+		}
+		if description == "" && field.Schema != nil && field.Schema.Ref != "" {
+			description = b.resolve(field.Schema).Description
+		}
+		if description != "" {
+			fmt.Fprintf(&body, "\t%s\n", goComment(description))
+		}
+		if field.Location != "" {
+			fmt.Fprintf(&body, "\t// Sent as the %s\n", field.Location)
+		}
+		tag := field.JSONName
+		if !field.Required {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(&body, "\t%s %s `json:%q`\n", fieldName, goType, tag)
+	}
+
+	b.decls[index] = fmt.Sprintf("// %s\ntype %s struct {\n%s}\n", comment, name, body.String())
+}
+
+// goType returns the Go type of a schema, declaring the named types it needs
+func (b *argsTypeBuilder) goType(name string, schema *converter.Schema) string {
+	if schema == nil {
+		return "any"
+	}
+	if schema.Ref != "" {
+		return b.refType(schema.Ref)
+	}
+
+	types := nonNullTypes(schema.Types)
+	if len(schema.AllOf) > 0 && (len(types) == 0 || types[0] == "object") {
+		merged := b.mergeAllOf(schema)
+		if merged == nil {
+			return "any"
+		}
+		schema, types = merged, []string{"object"}
+	}
+	if len(types) == 0 && schema.Object != nil && len(schema.Object.Properties) > 0 {
+		types = []string{"object"}
+	}
+	if len(types) != 1 || len(schema.OneOf) > 0 || len(schema.AnyOf) > 0 {
+		return "any"
+	}
+
+	var base string
+	switch types[0] {
+	case "string":
+		// Files are sent as base64 strings or resources, see mcputils.DecodeFile
+		if schema.Format == "binary" {
+			return "any"
+		}
+		base = "string"
+	case "integer":
+		base = "int"
+		if schema.Format == "int32" || schema.Format == "int64" {
+			base = schema.Format
+		}
+	case "number":
+		base = "float64"
+		if schema.Format == "float" {
+			base = "float32"
+		}
+	case "boolean":
+		return "bool"
+	case "array":
+		var items *converter.Schema
+		if schema.Array != nil {
+			items = schema.Array.Items
+		}
+		return "[]" + b.goType(name+"Item", items)
+	case "object":
+		if schema.Object == nil {
+			return "map[string]any"
+		}
+		if len(schema.Object.Properties) > 0 {
+			return b.objectType(name, schema)
+		}
+		if schema.Object.AdditionalProperties != nil {
+			return "map[string]" + b.goType(name+"Value", schema.Object.AdditionalProperties)
+		}
+		return "map[string]any"
+	default:
+		return "any"
+	}
+
+	if len(schema.Enum) > 0 {
+		return b.enumType(name, base, schema)
+	}
+	return base
+}
+
+// objectType declares the struct of an object schema with properties
+func (b *argsTypeBuilder) objectType(name string, schema *converter.Schema) string {
+	required := make(map[string]bool)
+	for _, property := range schema.Object.Required {
+		required[property] = true
+	}
+
+	properties := make([]string, 0, len(schema.Object.Properties))
+	for property := range schema.Object.Properties {
+		properties = append(properties, property)
+	}
+	sort.Strings(properties)
+
+	fields := make([]structField, 0, len(properties))
+	for _, property := range properties {
+		fields = append(fields, structField{
+			JSONName: property,
+			Schema:   schema.Object.Properties[property],
+			Required: required[property],
+		})
+	}
+
+	name = b.uniqueName(name)
+	comment := name + " is an object argument"
+	if description := strings.TrimSpace(schema.Description); description != "" {
+		comment += "\n// " + strings.ReplaceAll(description, "\n", "\n// ")
+	}
+	b.declareStruct(name, comment, fields)
+	return name
+}
+
+// enumType declares a named type with a constant per enum value
+func (b *argsTypeBuilder) enumType(name, base string, schema *converter.Schema) string {
+	name = b.uniqueName(name)
+
+	var consts strings.Builder
+	for _, value := range schema.Enum {
+		literal, suffix, ok := enumLiteral(base, value)
+		if !ok {
+			continue
+		}
+		constName := b.uniqueName(name + suffix)
+		fmt.Fprintf(&consts, "\t%s %s = %s\n", constName, name, literal)
+	}
+
+	decl := fmt.Sprintf("// %s is the set of accepted values\ntype %s %s\n", name, name, base)
+	if consts.Len() > 0 {
+		decl += fmt.Sprintf("\n// Accepted values of %s\nconst (\n%s)\n", name, consts.String())
+	}
+	b.decls = append(b.decls, decl)
+	return name
+}
+
+// refType returns the Go type of a referenced component schema, declaring it on first use.
+// A struct referencing itself does so through a pointer.
+func (b *argsTypeBuilder) refType(ref string) string {
+	if goType, ok := b.refs[ref]; ok {
+		return goType
+	}
+	if name, ok := b.resolving[ref]; ok {
+		if name == "" {
+			return "any"
+		}
+		return "*" + name
+	}
+
+	definition := b.definitions[ref]
+	name := b.toolNameGo + goIdentifier(ref)
+	b.resolving[ref] = ""
+	if isStruct(definition) {
+		// The name the struct will be declared with, for the references to it from its own fields
+		b.resolving[ref] = availableIdentifier(name, b.used)
+	}
+	goType := b.goType(name, definition)
+	delete(b.resolving, ref)
+
+	b.refs[ref] = goType
+	return goType
+}
+
+// resolve returns the component schema a schema references, or the schema itself
+func (b *argsTypeBuilder) resolve(schema *converter.Schema) *converter.Schema {
+	if schema != nil && schema.Ref != "" {
+		return b.definitions[schema.Ref]
+	}
+	return schema
+}
+
+// uniqueName returns a type or constant name not declared yet for the tool
+func (b *argsTypeBuilder) uniqueName(name string) string {
+	return uniqueIdentifier(name, b.used)
+}
+
+// enumLiteral returns the Go literal of an enum value and the suffix of its constant name.
+// Values that do not match the base type are skipped.
+func enumLiteral(base string, value any) (literal, suffix string, ok bool) {
+	switch v := value.(type) {
+	case string:
+		if base != "string" {
+			return "", "", false
+		}
+		suffix = goIdentifier(v)
+		if v == "" {
+			suffix = "Empty"
+		}
+		return strconv.Quote(v), suffix, true
+	case float64:
+		if base == "string" || (strings.HasPrefix(base, "int") && v != float64(int64(v))) {
+			return "", "", false
+		}
+		literal = strconv.FormatFloat(v, 'f', -1, 64)
+		suffix = strings.NewReplacer("-", "Minus", ".", "_").Replace(literal)
+		return literal, "Value" + suffix, true
+	default:
+		return "", "", false
+	}
+}
+
+// mergeAllOf merges the properties of allOf object schemas, nil when a part is not an object
+func (b *argsTypeBuilder) mergeAllOf(schema *converter.Schema) *converter.Schema {
+	merged := &converter.Schema{
+		Description: schema.Description,
+		Object:      &converter.ObjectValidation{Properties: make(map[string]*converter.Schema)},
+	}
+
+	parts := append([]*converter.Schema{schema}, schema.AllOf...)
+	for i, part := range parts {
+		if i > 0 {
+			part = b.resolve(part)
+		}
+		if part == nil {
+			return nil
+		}
+		if len(part.AllOf) > 0 && i > 0 {
+			part = b.mergeAllOf(part)
+			if part == nil {
+				return nil
+			}
+		}
+		if part.Object == nil {
+			if i == 0 {
+				continue
+			}
+			return nil
+		}
+		for property, propertySchema := range part.Object.Properties {
+			merged.Object.Properties[property] = propertySchema
+		}
+		merged.Object.Required = append(merged.Object.Required, part.Object.Required...)
+	}
+	return merged
+}
+
+// nonNullTypes returns the types of a schema without "null"
+func nonNullTypes(types []string) []string {
+	var result []string
+	for _, t := range types {
+		if t != "null" {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// isStruct reports whether a schema is converted to a struct
+func isStruct(schema *converter.Schema) bool {
+	if schema == nil || schema.Object == nil || len(schema.Object.Properties) == 0 {
+		return false
+	}
+	if len(schema.AllOf) > 0 || len(schema.OneOf) > 0 || len(schema.AnyOf) > 0 {
+		return false
+	}
+	types := nonNullTypes(schema.Types)
+	return len(types) == 0 || (len(types) == 1 && types[0] == "object")
+}
+
+// isNullable reports whether a schema accepts null
+func isNullable(schema *converter.Schema) bool {
+	if schema == nil {
+		return false
+	}
+	for _, t := range schema.Types {
+		if t == "null" {
+			return true
+		}
+	}
+	return false
+}
+
+// optionalType returns the type of an optional field, slices, maps and any already have nil
+func optionalType(goType string) string {
+	if isNilable(goType) {
+		return goType
+	}
+	return "*" + goType
+}
+
+// isNilable reports whether a Go type has nil as its zero value
+func isNilable(goType string) bool {
+	return goType == "any" || strings.HasPrefix(goType, "*") || strings.HasPrefix(goType, "[]") || strings.HasPrefix(goType, "map[")
+}
+
+// goIdentifier converts a JSON name such as "todo_id" or "X-Request-ID" to an exported Go identifier
+func goIdentifier(name string) string {
+	var b strings.Builder
+	for _, word := range splitWords(name) {
+		b.WriteString(capitalizeFirstLetter(word))
+	}
+	identifier := b.String()
+	if identifier == "" {
+		return "Field"
+	}
+	if unicode.IsDigit([]rune(identifier)[0]) {
+		return "N" + identifier
+	}
+	return identifier
+}
+
+// uniqueIdentifier returns the identifier, numbered when already used, and marks it as used
+func uniqueIdentifier(identifier string, used map[string]bool) string {
+	unique := availableIdentifier(identifier, used)
+	used[unique] = true
+	return unique
+}
+
+// availableIdentifier returns the identifier, numbered when already used
+func availableIdentifier(identifier string, used map[string]bool) string {
+	unique := identifier
+	for i := 2; used[unique]; i++ {
+		unique = identifier + strconv.Itoa(i)
+	}
+	return unique
+}
+
+// goComment turns a description into a line comment
+func goComment(description string) string {
+	return "// " + strings.ReplaceAll(strings.TrimSpace(description), "\n", "\n\t// ")
+}
@@ -0,0 +1,167 @@
+package generator
+
+import (
+	"go/format"
+	"strings"
+	"testing"
+
+	"github.com/lyeslabs/mcpgen/internal/converter"
+)
+
+func TestArgsTypes(t *testing.T) {
+	args := []converter.Arg{
+		{Name: "todo-id", Source: "path", Required: true, Description: "ID of the todo", Schema: &converter.Schema{Types: []string{"integer"}, Format: "int64"}},
+		{Name: "status", Source: "query", Schema: &converter.Schema{Types: []string{"string"}, Enum: []interface{}{"in-progress", "done"}}},
+		{Name: "tags", Source: "query", Schema: &converter.Schema{Types: []string{"array"}, Array: &converter.ArrayValidation{Items: &converter.Schema{Types: []string{"string"}}}}},
+		{Name: "note", Source: "header", Required: true, Schema: &converter.Schema{Types: []string{"string", "null"}}},
+		{Name: "filter", Source: "query", Schema: &converter.Schema{OneOf: []*converter.Schema{{Types: []string{"string"}}, {Types: []string{"integer"}}}}},
+		{Name: "body", Source: "body", Required: true, ContentTypes: map[string]*converter.Schema{
+			"application/json": {
+				Types: []string{"object"},
+				Object: &converter.ObjectValidation{
+					Required: []string{"title"},
+					Properties: map[string]*converter.Schema{
+						"title":    {Types: []string{"string"}},
+						"priority": {Types: []string{"integer"}, Enum: []interface{}{float64(1), float64(2)}},
+						"labels":   {Types: []string{"object"}, Object: &converter.ObjectValidation{AdditionalProperties: &converter.Schema{Types: []string{"number"}}}},
+						"document": {Types: []string{"string"}, Format: "binary"},
+					},
+				},
+			},
+		}},
+	}
+
+	code := argsTypes("UpdateTodo", args, nil)
+	formatted, err := format.Source([]byte("package mcptools\n\n" + code))
+	if err != nil {
+		t.Fatalf("generated code does not parse: %v\n%s", err, code)
+	}
+
+	for _, want := range []string{
+		"type UpdateTodoArgs struct {",
+		"// ID of the todo\n\tTodoId int64                 `json:\"todo-id\"`",
+		"Status *UpdateTodoArgsStatus `json:\"status,omitempty\"`",
+		"Tags   []string              `json:\"tags,omitempty\"`",
+		"Note   *string               `json:\"note\"`",
+		"Filter any                   `json:\"filter,omitempty\"`",
+		"Body   UpdateTodoArgsBody    `json:\"body\"`",
+		"UpdateTodoArgsStatusInProgress UpdateTodoArgsStatus = \"in-progress\"",
+		"Document any                         `json:\"document,omitempty\"`",
+		"Labels   map[string]float64          `json:\"labels,omitempty\"`",
+		"Priority *UpdateTodoArgsBodyPriority `json:\"priority,omitempty\"`",
+		"Title    string                      `json:\"title\"`",
+		"type UpdateTodoArgsBodyPriority int",
+		"UpdateTodoArgsBodyPriorityValue1 UpdateTodoArgsBodyPriority = 1",
+	} {
+		if !strings.Contains(string(formatted), want) {
+			t.Errorf("generated code missing %q:\n%s", want, formatted)
+		}
+	}
+}
+
+func TestArgsTypes_AllOf(t *testing.T) {
+	args := []converter.Arg{
+		{Name: "body", Source: "body", Required: true, Schema: &converter.Schema{AllOf: []*converter.Schema{
+			{Types: []string{"object"}, Object: &converter.ObjectValidation{Required: []string{"id"}, Properties: map[string]*converter.Schema{"id": {Types: []string{"string"}}}}},
+			{Types: []string{"object"}, Object: &converter.ObjectValidation{Properties: map[string]*converter.Schema{"done": {Types: []string{"boolean"}}}}},
+		}}},
+	}
+
+	formatted, err := format.Source([]byte("package mcptools\n\n" + argsTypes("PatchTodo", args, nil)))
+	if err != nil {
+		t.Fatalf("generated code does not parse: %v", err)
+	}
+	for _, want := range []string{"Done *bool  `json:\"done,omitempty\"`", "Id   string `json:\"id\"`"} {
+		if !strings.Contains(string(formatted), want) {
+			t.Errorf("generated code missing %q:\n%s", want, formatted)
+		}
+	}
+}
+
+func TestArgsTypes_Definitions(t *testing.T) {
+	definitions := map[string]*converter.Schema{
+		"Node": {Types: []string{"object"}, Object: &converter.ObjectValidation{
+			Required: []string{"parent"},
+			Properties: map[string]*converter.Schema{
+				"parent":   {Ref: "Node"},
+				"children": {Types: []string{"array"}, Array: &converter.ArrayValidation{Items: &converter.Schema{Ref: "Node"}}},
+				"color":    {Ref: "Color"},
+			},
+		}},
+		"Color": {Types: []string{"string"}, Enum: []interface{}{"red"}},
+	}
+	args := []converter.Arg{
+		{Name: "root", Source: "body", Required: true, Schema: &converter.Schema{Ref: "Node"}},
+		{Name: "other", Source: "query", Schema: &converter.Schema{Ref: "Node"}},
+	}
+
+	formatted, err := format.Source([]byte("package mcptools\n\n" + argsTypes("Graph", args, definitions)))
+	if err != nil {
+		t.Fatalf("generated code does not parse: %v", err)
+	}
+	for _, want := range []string{
+		"Root  GraphNode  `json:\"root\"`",
+		"Other *GraphNode `json:\"other,omitempty\"`",
+		"type GraphNode struct {",
+		"Children []*GraphNode `json:\"children,omitempty\"`",
+		"Color    *GraphColor  `json:\"color,omitempty\"`",
+		"Parent   *GraphNode   `json:\"parent\"`",
+		"GraphColorRed GraphColor = \"red\"",
+	} {
+		if !strings.Contains(string(formatted), want) {
+			t.Errorf("generated code missing %q:\n%s", want, formatted)
+		}
+	}
+	if strings.Count(string(formatted), "type GraphNode struct") != 1 {
+		t.Errorf("expected GraphNode to be declared once:\n%s", formatted)
+	}
+}
+
+func Test_goIdentifier(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"todo_id", "TodoId"},
+		{"X-Request-ID", "XRequestID"},
+		{"2fa", "N2fa"},
+		{"$", "Field"},
+	}
+	for _, tt := range tests {
+		if got := goIdentifier(tt.in); got != tt.want {
+			t.Errorf("goIdentifier(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestArgsTypes_FlattenedBody(t *testing.T) {
+	args := []converter.Arg{
+		{Name: "id", Source: "path", Required: true, Schema: &converter.Schema{Types: []string{"string"}}},
+		{Name: "body_id", Source: "body", BodyProperty: "id", Schema: &converter.Schema{Types: []string{"string"}}},
+		{Name: "name", Source: "body", BodyProperty: "name", Required: true, Schema: &converter.Schema{Types: []string{"string"}}},
+	}
+
+	formatted, err := format.Source([]byte("package mcptools\n\n" + argsTypes("UpdateItem", args, nil)))
+	if err != nil {
+		t.Fatalf("generated code does not parse: %v", err)
+	}
+	want := `func (a *UpdateItemArgs) RequestBody() map[string]any {
+	body := make(map[string]any)
+	if a.BodyId != nil {
+		body["id"] = a.BodyId
+	}
+	body["name"] = a.Name
+	return body
+}`
+	if !strings.Contains(string(formatted), want) {
+		t.Errorf("generated code missing %q:\n%s", want, formatted)
+	}
+	if want := "// Sent as the body property \"id\"\n\tBodyId *string"; !strings.Contains(string(formatted), want) {
+		t.Errorf("generated code missing %q:\n%s", want, formatted)
+	}
+
+	// Tools without flattened body arguments have no RequestBody method
+	if code := argsTypes("GetItem", args[:1], nil); strings.Contains(code, "RequestBody") {
+		t.Errorf("unexpected RequestBody method:\n%s", code)
+	}
+}
@@ -0,0 +1,220 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lyeslabs/mcpgen/internal/converter"
+	"github.com/oasdiff/yaml"
+)
+
+// DefaultConfigFile is the configuration file read from the working directory when none is given
+const DefaultConfigFile = "mcpgen.yaml"
+
+// Defaults of the generated server
+const (
+	DefaultPackageName   = "mcpgen"
+	DefaultServerName    = "MCP Server"
+	DefaultServerVersion = "1.0.0"
+)
+
+// Config is the project configuration, usually read from mcpgen.yaml.
+// Its JSON Schema is mcpgen.schema.json at the root of the repository.
+type Config struct {
+	Input             string                    `json:"input,omitempty"`
+	Output            string                    `json:"output,omitempty"`
+	Package           string                    `json:"package,omitempty"`
+	Validation        bool                      `json:"validation,omitempty"`
+	Includes          []string                  `json:"includes,omitempty"`
+	Handlers          string                    `json:"handlers,omitempty"`
+	CacheDir          string                    `json:"cacheDir,omitempty"`
+	Filters           converter.ToolFilter      `json:"filters,omitempty"`
+	Resources         converter.ResourceOptions `json:"resources,omitempty"`
+	Prompts           string                    `json:"prompts,omitempty"`
+	SharedDefinitions bool                      `json:"sharedDefinitions,omitempty"`
+	FlattenBody       bool                      `json:"flattenBody,omitempty"`
+	SchemaBudget      int                       `json:"schemaBudget,omitempty"`   // Maximum size of the tool input schemas in bytes
+	ContentTypes      []string                  `json:"contentTypes,omitempty"`   // Preference order of request body content types
+	ProtocolErrors    []string                  `json:"protocolErrors,omitempty"` // Upstream status codes returned as protocol errors
+	Transport         TransportConfig           `json:"transport,omitempty"`
+	Naming            NamingConfig              `json:"naming,omitempty"`
+	Server            ServerInfo                `json:"server,omitempty"`
+	Tools             map[string]ToolOverride   `json:"tools,omitempty"` // Keyed by operationId
+}
+
+// ServerInfo is the name and version the generated server reports to clients
+type ServerInfo struct {
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+// LoadConfig reads a configuration file.
+// Relative paths in the file are resolved against the directory of the file.
+func LoadConfig(configPath string) (*Config, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var config Config
+	// Unknown keys are rejected so that typos do not silently fall back to defaults
+	if err := yaml.Unmarshal(data, &config, func(decoder *json.Decoder) *json.Decoder {
+		decoder.DisallowUnknownFields()
+		return decoder
+	}); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", configPath, err)
+	}
+
+	dir := filepath.Dir(configPath)
+	config.Input = resolveConfigPath(dir, config.Input)
+	config.Output = resolveConfigPath(dir, config.Output)
+	config.CacheDir = resolveConfigPath(dir, config.CacheDir)
+
+	return &config, nil
+}
+
+// resolveConfigPath makes a relative file path relative to the config directory, URLs are kept
+func resolveConfigPath(dir, value string) string {
+	if value == "" || filepath.IsAbs(value) || strings.Contains(value, "://") {
+		return value
+	}
+	return filepath.Join(dir, value)
+}
+
+// SetDefaults fills the unset values with their defaults
+func (c *Config) SetDefaults() {
+	if c.Package == "" {
+		c.Package = DefaultPackageName
+	}
+	if c.Handlers == "" {
+		c.Handlers = HandlerModeStub
+	}
+	if c.Naming.Style == "" {
+		c.Naming.Style = NamingStylePascal
+	}
+	if c.Server.Name == "" {
+		c.Server.Name = DefaultServerName
+	}
+	if c.Server.Version == "" {
+		c.Server.Version = DefaultServerVersion
+	}
+}
+
+// Validate checks that the configuration is complete and consistent
+func (c *Config) Validate() error {
+	if c.Input == "" {
+		return fmt.Errorf("input is required")
+	}
+	if c.Output == "" {
+		return fmt.Errorf("output is required")
+	}
+	if c.Handlers != HandlerModeStub && c.Handlers != HandlerModeProxy {
+		return fmt.Errorf("unknown handlers mode %q", c.Handlers)
+	}
+	if c.SchemaBudget < 0 {
+		return fmt.Errorf("schema budget must not be negative, got %d", c.SchemaBudget)
+	}
+	for _, code := range c.ProtocolErrors {
+		if !isErrorStatus(code) {
+			return fmt.Errorf("invalid protocol error %q (must be a 4xx or 5xx status code, or a range such as 5XX)", code)
+		}
+	}
+	if _, err := requestPolicy(c.Transport.Timeout, c.Transport.Retry); err != nil {
+		return fmt.Errorf("invalid transport: %w", err)
+	}
+	if c.Transport.RateLimit.RequestsPerSecond < 0 || c.Transport.RateLimit.Burst < 0 {
+		return fmt.Errorf("rate limit must not be negative")
+	}
+	for operationID, override := range c.Tools {
+		if _, err := requestPolicy(override.Timeout, override.Retry); err != nil {
+			return fmt.Errorf("invalid policy of tool %s: %w", operationID, err)
+		}
+	}
+	switch c.Prompts {
+	case PromptModeNone, PromptModeTool, PromptModeTag:
+	default:
+		return fmt.Errorf("unknown prompts mode %q", c.Prompts)
+	}
+	switch c.Naming.Style {
+	case NamingStylePascal, NamingStyleCamel, NamingStyleSnake, NamingStyleKebab, NamingStyleOriginal:
+	default:
+		return fmt.Errorf("unknown naming style %q", c.Naming.Style)
+	}
+	for _, include := range c.Includes {
+		switch strings.ToLower(include) {
+		case "httpclient", "types":
+		default:
+			return fmt.Errorf("unknown include %q (must be 'httpclient' or 'types')", include)
+		}
+	}
+	return nil
+}
+
+// NewGeneratorFromConfig parses the configured specification and creates a generator using the configuration
+func NewGeneratorFromConfig(config *Config) (*Generator, error) {
+	parser := converter.NewParser(config.Validation)
+	parser.CacheDir = config.CacheDir
+
+	g, err := NewGeneratorWithParser(parser, config.Input, config.Package, config.Output)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Handlers != "" {
+		g.HandlerMode = config.Handlers
+	}
+	g.PromptMode = config.Prompts
+	g.Server = config.Server
+	g.Naming = config.Naming
+	g.ToolOverrides = config.Tools
+	g.ProtocolErrors = config.ProtocolErrors
+	g.Transport = config.Transport
+
+	if err := g.SetToolFilter(&config.Filters); err != nil {
+		return nil, err
+	}
+	if err := g.SetResourceOptions(config.Resources); err != nil {
+		return nil, err
+	}
+	if config.SharedDefinitions {
+		if err := g.SetSharedDefinitions(true); err != nil {
+			return nil, err
+		}
+	}
+	if config.FlattenBody {
+		if err := g.SetFlattenBody(true); err != nil {
+			return nil, err
+		}
+	}
+	if err := g.SetSchemaBudget(config.SchemaBudget); err != nil {
+		return nil, err
+	}
+	if len(config.ContentTypes) > 0 {
+		if err := g.SetContentTypes(config.ContentTypes); err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
+
+// valueOrDefault returns the value, or the fallback when the value is empty
+func valueOrDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// isErrorStatus reports whether a code is a 4xx or 5xx status code, or the 4XX or 5XX range
+func isErrorStatus(code string) bool {
+	if len(code) != 3 || (code[0] != '4' && code[0] != '5') {
+		return false
+	}
+	if strings.EqualFold(code[1:], "XX") {
+		return true
+	}
+	return code[1] >= '0' && code[1] <= '9' && code[2] >= '0' && code[2] <= '9'
+}
@@ -0,0 +1,271 @@
+package generator
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/lyeslabs/mcpgen/internal/converter"
+)
+
+func writeConfigFile(t *testing.T, dir, content string) string {
+	t.Helper()
+	configPath := filepath.Join(dir, DefaultConfigFile)
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	return configPath
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	configPath := writeConfigFile(t, dir, `
+input: api/openapi.yaml
+output: ./gen
+package: todos
+includes: [types]
+handlers: proxy
+cacheDir: /var/cache/mcpgen
+sharedDefinitions: true
+flattenBody: true
+schemaBudget: 4096
+contentTypes: [application/json, multipart/form-data]
+protocolErrors: [5XX, "429"]
+transport:
+  timeout: 10s
+  retry:
+    maxAttempts: 5
+  rateLimit:
+    requestsPerSecond: 2.5
+    burst: 5
+filters:
+  includeMethods: [get]
+naming:
+  style: snake
+  prefix: todo_
+server:
+  name: Todo Server
+  version: 2.0.0
+tools:
+  listTodos:
+    name: list_all_todos
+    description: Lists every todo
+    schemaBudget: 8192
+    timeout: 2m
+`)
+
+	config, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	want := &Config{
+		Input:             filepath.Join(dir, "api", "openapi.yaml"),
+		Output:            filepath.Join(dir, "gen"),
+		Package:           "todos",
+		Includes:          []string{"types"},
+		Handlers:          HandlerModeProxy,
+		CacheDir:          "/var/cache/mcpgen",
+		SharedDefinitions: true,
+		FlattenBody:       true,
+		SchemaBudget:      4096,
+		ContentTypes:      []string{"application/json", "multipart/form-data"},
+		ProtocolErrors:    []string{"5XX", "429"},
+		Filters:           converter.ToolFilter{IncludeMethods: []string{"get"}},
+		Naming:            NamingConfig{Style: NamingStyleSnake, Prefix: "todo_"},
+		Server:            ServerInfo{Name: "Todo Server", Version: "2.0.0"},
+		Transport: TransportConfig{
+			Timeout:   "10s",
+			Retry:     RetryConfig{MaxAttempts: 5},
+			RateLimit: RateLimitConfig{RequestsPerSecond: 2.5, Burst: 5},
+		},
+		Tools: map[string]ToolOverride{
+			"listTodos": {Name: "list_all_todos", Description: "Lists every todo", SchemaBudget: 8192, Timeout: "2m"},
+		},
+	}
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("LoadConfig() = %+v, want %+v", config, want)
+	}
+}
+
+func TestLoadConfig_URLInputAndUnknownKeys(t *testing.T) {
+	dir := t.TempDir()
+
+	config, err := LoadConfig(writeConfigFile(t, dir, "input: https://example.com/openapi.yaml\n"))
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if config.Input != "https://example.com/openapi.yaml" {
+		t.Errorf("Input = %q, want the URL unchanged", config.Input)
+	}
+
+	if _, err := LoadConfig(writeConfigFile(t, dir, "input: api.yaml\noutptu: gen\n")); err == nil {
+		t.Error("LoadConfig() error = nil, want error for an unknown key")
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *Config)
+		wantErr string
+	}{
+		{"valid", func(c *Config) {}, ""},
+		{"missing input", func(c *Config) { c.Input = "" }, "input is required"},
+		{"missing output", func(c *Config) { c.Output = "" }, "output is required"},
+		{"unknown handlers", func(c *Config) { c.Handlers = "mock" }, "unknown handlers mode"},
+		{"unknown naming style", func(c *Config) { c.Naming.Style = "upper" }, "unknown naming style"},
+		{"unknown prompts mode", func(c *Config) { c.Prompts = "operation" }, "unknown prompts mode"},
+		{"unknown include", func(c *Config) { c.Includes = []string{"server"} }, "unknown include"},
+		{"negative schema budget", func(c *Config) { c.SchemaBudget = -1 }, "schema budget must not be negative"},
+		{"protocol error range", func(c *Config) { c.ProtocolErrors = []string{"5xx", "401"} }, ""},
+		{"invalid protocol error", func(c *Config) { c.ProtocolErrors = []string{"200"} }, "invalid protocol error"},
+		{"invalid timeout", func(c *Config) { c.Transport.Timeout = "30" }, "invalid transport: invalid timeout"},
+		{"negative max attempts", func(c *Config) { c.Transport.Retry.MaxAttempts = -1 }, "maxAttempts must not be negative"},
+		{"negative rate limit", func(c *Config) { c.Transport.RateLimit.RequestsPerSecond = -1 }, "rate limit must not be negative"},
+		{"invalid tool backoff", func(c *Config) {
+			c.Tools = map[string]ToolOverride{"listTodos": {Retry: RetryConfig{MaxBackoff: "0s"}}}
+		}, "invalid policy of tool listTodos: maxBackoff must be positive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{Input: "openapi.yaml", Output: "gen", Includes: []string{"httpclient", "Types"}}
+			config.SetDefaults()
+			tt.modify(config)
+
+			err := config.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_SetDefaults(t *testing.T) {
+	config := &Config{Package: "custom"}
+	config.SetDefaults()
+
+	if config.Package != "custom" {
+		t.Errorf("Package = %q, want the configured value kept", config.Package)
+	}
+	if config.Handlers != HandlerModeStub || config.Naming.Style != NamingStylePascal {
+		t.Errorf("unexpected defaults: %+v", config)
+	}
+	if config.Server.Name != DefaultServerName || config.Server.Version != DefaultServerVersion {
+		t.Errorf("Server = %+v, want defaults", config.Server)
+	}
+}
+
+// The JSON Schema must describe exactly the fields of the configuration types
+func TestConfigSchemaMatchesConfig(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "mcpgen.schema.json"))
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	var schema map[string]any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("Invalid schema: %v", err)
+	}
+
+	properties := func(node any) []string {
+		var keys []string
+		for key := range node.(map[string]any)["properties"].(map[string]any) {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return keys
+	}
+	fields := func(v any) []string {
+		var keys []string
+		typ := reflect.TypeOf(v)
+		for i := 0; i < typ.NumField(); i++ {
+			keys = append(keys, strings.Split(typ.Field(i).Tag.Get("json"), ",")[0])
+		}
+		sort.Strings(keys)
+		return keys
+	}
+
+	root := schema["properties"].(map[string]any)
+	definitions := schema["definitions"].(map[string]any)
+	tests := []struct {
+		name   string
+		schema any
+		value  any
+	}{
+		{"config", schema, Config{}},
+		{"filters", root["filters"], converter.ToolFilter{}},
+		{"naming", root["naming"], NamingConfig{}},
+		{"server", root["server"], ServerInfo{}},
+		{"tools", definitions["toolOverride"], ToolOverride{}},
+		{"transport", root["transport"], TransportConfig{}},
+		{"retry", definitions["retry"], RetryConfig{}},
+		{"rateLimit", root["transport"].(map[string]any)["properties"].(map[string]any)["rateLimit"], RateLimitConfig{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, want := properties(tt.schema), fields(tt.value); !reflect.DeepEqual(got, want) {
+				t.Errorf("schema properties = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestNewGeneratorFromConfig(t *testing.T) {
+	outputDir := t.TempDir()
+	config := &Config{
+		Input:   filepath.Join("..", "..", "testdata", "todoopenapi.yaml"),
+		Output:  outputDir,
+		Filters: converter.ToolFilter{IncludeOperationIDs: []string{"listTodos", "createTodo"}},
+		Naming:  NamingConfig{Style: NamingStyleSnake},
+		Server:  ServerInfo{Name: "Todo Server", Version: "2.0.0"},
+		Tools: map[string]ToolOverride{
+			"createTodo": {Name: "add_todo", Description: "Adds a todo\nwith \"quotes\""},
+		},
+	}
+	config.SetDefaults()
+
+	gen, err := NewGeneratorFromConfig(config)
+	if err != nil {
+		t.Fatalf("NewGeneratorFromConfig() error = %v", err)
+	}
+	if err := gen.GenerateMCP(); err != nil {
+		t.Fatalf("GenerateMCP() error = %v", err)
+	}
+
+	server, err := os.ReadFile(filepath.Join(outputDir, "server.go"))
+	if err != nil {
+		t.Fatalf("Failed to read server.go: %v", err)
+	}
+	if !strings.Contains(string(server), `"Todo Server"`) || !strings.Contains(string(server), `"2.0.0"`) {
+		t.Errorf("server.go does not use the configured name and version:\n%s", server)
+	}
+	if strings.Contains(string(server), "GetTodoById") {
+		t.Error("server.go registers a filtered out tool")
+	}
+
+	listTodos, err := os.ReadFile(filepath.Join(outputDir, "mcptools", "ListTodos.go"))
+	if err != nil {
+		t.Fatalf("Failed to read ListTodos.go: %v", err)
+	}
+	if !strings.Contains(string(listTodos), `"list_todos",`) || !strings.Contains(string(listTodos), "func NewListTodosMCPTool()") {
+		t.Errorf("ListTodos.go does not expose the snake case name with Go identifiers unchanged:\n%s", listTodos)
+	}
+
+	createTodo, err := os.ReadFile(filepath.Join(outputDir, "mcptools", "CreateTodo.go"))
+	if err != nil {
+		t.Fatalf("Failed to read CreateTodo.go: %v", err)
+	}
+	if !strings.Contains(string(createTodo), `"add_todo",`) || !strings.Contains(string(createTodo), `"Adds a todo\nwith \"quotes\"",`) {
+		t.Errorf("CreateTodo.go does not use the override:\n%s", createTodo)
+	}
+}
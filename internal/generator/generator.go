@@ -7,17 +7,36 @@ import (
 	"github.com/lyeslabs/mcpgen/internal/converter"
 )
 
+// Handler generation modes
+const (
+	HandlerModeStub  = "stub"  // Handler skeletons to be implemented by hand
+	HandlerModeProxy = "proxy" // Handlers forwarding tool calls to the upstream API
+)
+
 type Generator struct {
-	specPath    string
-	PackageName string
-	outputDir   string
-	converter   converter.ConverterInterface
-	spec        *openapi3.T
+	specPath      string
+	PackageName   string
+	HandlerMode   string
+	PromptMode    string
+	Server        ServerInfo
+	Naming        NamingConfig
+	ToolOverrides map[string]ToolOverride // Keyed by operationId
+	// Upstream status codes, or ranges such as 5XX, that proxy handlers return as protocol errors instead of tool errors
+	ProtocolErrors []string
+	Transport      TransportConfig
+	outputDir      string
+	converter      converter.ConverterInterface
+	spec           *openapi3.T
 }
 
 func NewGenerator(specPath string, validation bool, packageName string, outputDir string) (*Generator, error) {
-	parser := converter.NewParser(validation)
-	err := parser.ParseFile(specPath)
+	return NewGeneratorWithParser(converter.NewParser(validation), specPath, packageName, outputDir)
+}
+
+// NewGeneratorWithParser creates a generator using a configured parser.
+// specPath can be a file path or an http(s):// or file:// URL.
+func NewGeneratorWithParser(parser *converter.Parser, specPath string, packageName string, outputDir string) (*Generator, error) {
+	err := parser.ParseLocation(specPath)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing OpenAPI specification: %w", err)
 	}
@@ -28,5 +47,85 @@ func NewGenerator(specPath string, validation bool, packageName string, outputDi
 		spec:        parser.GetDocument(),
 		outputDir:   outputDir,
 		PackageName: packageName,
+		HandlerMode: HandlerModeStub,
 	}, nil
 }
+
+// SetToolFilter restricts the generated tools to the operations selected by the filter
+func (g *Generator) SetToolFilter(filter *converter.ToolFilter) error {
+	c, ok := g.converter.(*converter.Converter)
+	if !ok {
+		return fmt.Errorf("tool filtering is not supported by the configured converter")
+	}
+	if err := c.SetToolFilter(filter); err != nil {
+		return fmt.Errorf("invalid tool filter: %w", err)
+	}
+	return nil
+}
+
+// SetSharedDefinitions makes the tool schemas reference the component schemas they use under $defs instead of inlining them
+func (g *Generator) SetSharedDefinitions(enabled bool) error {
+	c, ok := g.converter.(*converter.Converter)
+	if !ok {
+		return fmt.Errorf("shared definitions are not supported by the configured converter")
+	}
+	c.SetSharedDefinitions(enabled)
+	return nil
+}
+
+// SetFlattenBody makes the properties of object request bodies tool arguments of their own
+func (g *Generator) SetFlattenBody(enabled bool) error {
+	c, ok := g.converter.(*converter.Converter)
+	if !ok {
+		return fmt.Errorf("flattened request bodies are not supported by the configured converter")
+	}
+	c.SetFlattenBody(enabled)
+	return nil
+}
+
+// SetContentTypes sets the preference order of request body content types, media types or patterns such as application/*.
+// Content types matching none of them are dropped from the request bodies offering a preferred one.
+func (g *Generator) SetContentTypes(preference []string) error {
+	c, ok := g.converter.(*converter.Converter)
+	if !ok {
+		return fmt.Errorf("content type preferences are not supported by the configured converter")
+	}
+	if err := c.SetContentTypes(preference); err != nil {
+		return fmt.Errorf("invalid content types: %w", err)
+	}
+	return nil
+}
+
+// SetSchemaBudget limits the size of the tool input schemas in bytes, 0 for no limit.
+// Per-tool budgets of the tool overrides take precedence over maxBytes.
+func (g *Generator) SetSchemaBudget(maxBytes int) error {
+	c, ok := g.converter.(*converter.Converter)
+	if !ok {
+		return fmt.Errorf("schema budgets are not supported by the configured converter")
+	}
+	budget := converter.SchemaBudget{MaxBytes: maxBytes}
+	for operationID, override := range g.ToolOverrides {
+		if override.SchemaBudget != 0 {
+			if budget.Tools == nil {
+				budget.Tools = make(map[string]int)
+			}
+			budget.Tools[operationID] = override.SchemaBudget
+		}
+	}
+	if err := c.SetSchemaBudget(budget); err != nil {
+		return fmt.Errorf("invalid schema budget: %w", err)
+	}
+	return nil
+}
+
+// SetResourceOptions selects the operations and schemas generated as resources instead of tools
+func (g *Generator) SetResourceOptions(options converter.ResourceOptions) error {
+	c, ok := g.converter.(*converter.Converter)
+	if !ok {
+		return fmt.Errorf("resources are not supported by the configured converter")
+	}
+	if err := c.SetResourceOptions(options); err != nil {
+		return fmt.Errorf("invalid resource options: %w", err)
+	}
+	return nil
+}
@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/lyeslabs/mcpgen/internal/converter"
 )
 
 // Helper function to create a temporary spec file
@@ -145,3 +147,31 @@ func TestNewGenerator_Error_ParsingFailed(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerator_SetToolFilter(t *testing.T) {
+	specPath := filepath.Join("..", "..", "testdata", "todoopenapi.yaml")
+	gen, err := NewGenerator(specPath, false, "testpkg", t.TempDir())
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+
+	if err := gen.SetToolFilter(&converter.ToolFilter{IncludeMethods: []string{"get"}}); err != nil {
+		t.Fatalf("SetToolFilter() error = %v", err)
+	}
+	config, err := gen.converter.Convert()
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if len(config.Tools) != 2 || config.Tools[0].Name != "getTodoById" || config.Tools[1].Name != "listTodos" {
+		t.Errorf("expected only the GET tools, got %+v", config.Tools)
+	}
+
+	if err := gen.SetToolFilter(&converter.ToolFilter{ExcludeTags: []string{"regex:["}}); err == nil {
+		t.Error("SetToolFilter() error = nil, want error for an invalid pattern")
+	}
+
+	gen.converter = &testConverter{}
+	if err := gen.SetToolFilter(&converter.ToolFilter{}); err == nil {
+		t.Error("SetToolFilter() error = nil, want error for a converter without filtering")
+	}
+}
@@ -1,8 +1,10 @@
 package generator
 
 import (
+	"bytes"
 	"fmt"
 	"strings"
+	"text/template"
 
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
 )
@@ -47,5 +49,41 @@ func (g *Generator) GenerateHTTPClient(includes []string) error {
 		return fmt.Errorf("failed to write generated code to file: %w", err)
 	}
 
+	// The client can send its requests through the transport of the helpers
+	if generateClient {
+		if err := g.generateClientTransport(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// generateClientTransport writes the client option routing the API client through the helpers transport
+func (g *Generator) generateClientTransport() error {
+	helpersImportPath, err := BuildHelpersImportPath(g.outputDir)
+	if err != nil {
+		return fmt.Errorf("failed to build helpers import path: %w", err)
+	}
+
+	content, err := templatesFS.ReadFile("templates/apiclient.templ")
+	if err != nil {
+		return fmt.Errorf("failed to read client transport template: %w", err)
+	}
+	tmpl, err := template.New("apiclient").Parse(string(content))
+	if err != nil {
+		return fmt.Errorf("failed to parse client transport template: %w", err)
+	}
+
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, struct{ HelpersImportPath string }{helpersImportPath}); err != nil {
+		return fmt.Errorf("failed to execute client transport template: %w", err)
+	}
+
+	if err := writeFileContent(g.outputDir+"/apiclient", "transport.go", func() ([]byte, error) {
+		return buffer.Bytes(), nil
+	}); err != nil {
+		return fmt.Errorf("failed to write client transport: %w", err)
+	}
 	return nil
 }
@@ -30,6 +30,7 @@ func TestGenerateHTTPClient_WithFixture(t *testing.T) {
 		includes       []string
 		expectError    bool
 		expectFile     bool
+		expectOption   bool // apiclient/transport.go routes the client through the helpers transport
 		errorSubstring string
 	}{
 		{
@@ -38,14 +39,16 @@ func TestGenerateHTTPClient_WithFixture(t *testing.T) {
 			expectFile: true,
 		},
 		{
-			name:       "httpclient only",
-			includes:   []string{"httpclient"},
-			expectFile: true,
+			name:         "httpclient only",
+			includes:     []string{"httpclient"},
+			expectFile:   true,
+			expectOption: true,
 		},
 		{
-			name:       "both types and httpclient",
-			includes:   []string{"types", "httpclient"},
-			expectFile: true,
+			name:         "both types and httpclient",
+			includes:     []string{"types", "httpclient"},
+			expectFile:   true,
+			expectOption: true,
 		},
 		{
 			name:           "invalid include",
@@ -95,6 +98,13 @@ func TestGenerateHTTPClient_WithFixture(t *testing.T) {
 				if !strings.Contains(string(data), "package apiclient") {
 					t.Errorf("Generated file does not contain expected package declaration")
 				}
+				option, readErr := os.ReadFile(filepath.Join(outputDir, "apiclient", "transport.go"))
+				if (readErr == nil) != tc.expectOption {
+					t.Errorf("transport.go exists = %v, want %v", readErr == nil, tc.expectOption)
+				}
+				if tc.expectOption && !strings.Contains(string(option), "WithHTTPClient(mcputils.HTTPClient)") {
+					t.Errorf("transport.go does not use the helpers client:\n%s", option)
+				}
 			}
 		})
 	}
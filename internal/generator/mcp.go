@@ -22,13 +22,26 @@ type ToolTemplateData struct {
 	ResponseTemplateConst string
 }
 
-// GenerateMCP generates the MCP tool files while preserving existing handler implementations and imports
+// GenerateMCP generates the MCP tool and resource files while preserving existing handler implementations and imports
 func (g *Generator) GenerateMCP() error {
+	// Only proxy handlers follow the pages of list operations, stubs get no pagination arguments they could not honor
+	if c, ok := g.converter.(*converter.Converter); ok {
+		c.SetPagination(g.HandlerMode == HandlerModeProxy)
+	}
+
 	config, err := g.converter.Convert()
 	if err != nil {
 		return fmt.Errorf("failed at converting OpenAPI schema into MCP code %w", err)
 	}
 
+	if err := g.checkToolNames(config); err != nil {
+		return fmt.Errorf("invalid tool names: %w", err)
+	}
+
+	if err := g.applyToolPolicies(config); err != nil {
+		return fmt.Errorf("invalid request policies: %w", err)
+	}
+
 	if err := g.GenerateServerFile(config); err != nil {
 		return fmt.Errorf("failed to generate server file: %w", err)
 	}
@@ -37,6 +50,14 @@ func (g *Generator) GenerateMCP() error {
 		return fmt.Errorf("failed to generate tool files: %w", err)
 	}
 
+	if err := g.GenerateResourceFiles(config); err != nil {
+		return fmt.Errorf("failed to generate resource files: %w", err)
+	}
+
+	if err := g.GeneratePromptsFile(config); err != nil {
+		return fmt.Errorf("failed to generate prompts: %w", err)
+	}
+
 	if err := g.GenerateHelpers(); err != nil {
 		return fmt.Errorf("failed to generate helpers: %w", err)
 	}
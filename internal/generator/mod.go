@@ -15,23 +15,40 @@ const (
 
 // BuildImportPath finds the module root and builds the import path for mcptools
 func BuildImportPath(outputDir string) (string, error) {
+	return buildPackageImportPath(outputDir, "mcptools")
+}
+
+// BuildHelpersImportPath finds the module root and builds the import path for helpers
+func BuildHelpersImportPath(outputDir string) (string, error) {
+	return buildPackageImportPath(outputDir, "helpers")
+}
+
+// buildPackageImportPath builds the import path of a package generated under outputDir.
+// A relative outputDir is relative to the working directory.
+func buildPackageImportPath(outputDir, packageDir string) (string, error) {
 	// Get current working directory
 	cwd, err := os.Getwd()
 	if err != nil {
 		return "", fmt.Errorf("failed to get current directory: %w", err)
 	}
 
-	// Find module info
-	moduleName, moduleRoot, err := findModulePath(cwd)
-	if err != nil {
-		return "", fmt.Errorf("failed to find module: %w", err)
+	// Get absolute path of the package directory
+	packagePath := filepath.Join(outputDir, packageDir)
+	if !filepath.IsAbs(packagePath) {
+		packagePath = filepath.Join(cwd, packagePath)
 	}
 
-	// Get absolute path of mcptools directory
-	mcptoolsPath := filepath.Join(cwd, outputDir, "mcptools")
+	// Find the module the package belongs to, falling back to the module of the working directory
+	moduleName, moduleRoot, err := findModulePath(packagePath)
+	if err != nil {
+		moduleName, moduleRoot, err = findModulePath(cwd)
+		if err != nil {
+			return "", fmt.Errorf("failed to find module: %w", err)
+		}
+	}
 
-	// Calculate relative path from module root to mcptools
-	relPath, err := filepath.Rel(moduleRoot, mcptoolsPath)
+	// Calculate relative path from module root to the package
+	relPath, err := filepath.Rel(moduleRoot, packagePath)
 	if err != nil {
 		return "", fmt.Errorf("failed to calculate relative path: %w", err)
 	}
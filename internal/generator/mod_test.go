@@ -313,3 +313,47 @@ func TestBuildImportPath(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildHelpersImportPath(t *testing.T) {
+	_, cwdSimulated := setupTestModuleStructure(t, "app6", "example.com/app6")
+
+	originalCwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get original CWD: %v", err)
+	}
+	if err := os.Chdir(cwdSimulated); err != nil {
+		t.Fatalf("Failed to change CWD to %s: %v", cwdSimulated, err)
+	}
+	defer os.Chdir(originalCwd)
+
+	importPath, err := BuildHelpersImportPath("generated")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if importPath != "example.com/app6/generated/helpers" {
+		t.Errorf("Expected import path %q, got %q", "example.com/app6/generated/helpers", importPath)
+	}
+}
+
+func TestBuildImportPath_AbsoluteOutputDir(t *testing.T) {
+	// The output belongs to another module than the working directory
+	_, cwdSimulated := setupTestModuleStructure(t, "app7", "example.com/app7")
+	otherRoot, _ := setupTestModuleStructure(t, "app8", "example.com/app8")
+
+	originalCwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get original CWD: %v", err)
+	}
+	if err := os.Chdir(cwdSimulated); err != nil {
+		t.Fatalf("Failed to change CWD to %s: %v", cwdSimulated, err)
+	}
+	defer os.Chdir(originalCwd)
+
+	importPath, err := BuildImportPath(filepath.Join(otherRoot, "gen"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if importPath != "example.com/app8/gen/mcptools" {
+		t.Errorf("Expected import path %q, got %q", "example.com/app8/gen/mcptools", importPath)
+	}
+}
@@ -0,0 +1,121 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/lyeslabs/mcpgen/internal/converter"
+)
+
+// Naming styles of the tool names exposed to MCP clients
+const (
+	NamingStylePascal   = "pascal"   // ListTodos, the default
+	NamingStyleCamel    = "camel"    // listTodos
+	NamingStyleSnake    = "snake"    // list_todos
+	NamingStyleKebab    = "kebab"    // list-todos
+	NamingStyleOriginal = "original" // operationId as written in the specification
+)
+
+// NamingConfig controls how operationIds become the tool names exposed to MCP clients.
+// Go identifiers and file names are not affected so that handlers survive a naming change.
+type NamingConfig struct {
+	Style  string `json:"style,omitempty"`
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// ToolOverride replaces generated values of a single tool
+type ToolOverride struct {
+	Name         string      `json:"name,omitempty"`
+	Description  string      `json:"description,omitempty"`
+	SchemaBudget int         `json:"schemaBudget,omitempty"` // Replaces the default schema budget, in bytes
+	Timeout      string      `json:"timeout,omitempty"`      // Replaces x-mcp-timeout and the default timeout, e.g. 2m
+	Retry        RetryConfig `json:"retry,omitempty"`        // Replaces the values of x-mcp-retry and the default retry policy
+}
+
+// toolName returns the name a tool is exposed under to MCP clients.
+// Configured overrides come first, then x-mcp-name, then the naming rules.
+func (g *Generator) toolName(tool converter.Tool) string {
+	if override, ok := g.ToolOverrides[tool.Name]; ok && override.Name != "" {
+		return override.Name
+	}
+	if tool.MCPName != "" {
+		return tool.MCPName
+	}
+	return g.Naming.Prefix + applyNamingStyle(tool.Name, g.Naming.Style)
+}
+
+// toolDescription returns the description a tool is exposed with to MCP clients
+func (g *Generator) toolDescription(tool converter.Tool) string {
+	if override, ok := g.ToolOverrides[tool.Name]; ok && override.Description != "" {
+		return override.Description
+	}
+	return tool.Description
+}
+
+// checkToolNames makes sure naming rules and overrides did not give two tools the same name
+func (g *Generator) checkToolNames(config *converter.MCPConfig) error {
+	seen := make(map[string]string)
+	for _, tool := range config.Tools {
+		name := g.toolName(tool)
+		if other, ok := seen[name]; ok {
+			return fmt.Errorf("tools %s and %s are both named %q", other, tool.Name, name)
+		}
+		seen[name] = tool.Name
+	}
+	return nil
+}
+
+// applyNamingStyle converts a name to the given naming style
+func applyNamingStyle(name, style string) string {
+	words := splitWords(name)
+	switch style {
+	case NamingStyleOriginal:
+		return name
+	case NamingStyleCamel:
+		for i, word := range words {
+			if i == 0 {
+				words[i] = strings.ToLower(word)
+			} else {
+				words[i] = capitalizeFirstLetter(strings.ToLower(word))
+			}
+		}
+		return strings.Join(words, "")
+	case NamingStyleSnake:
+		return strings.ToLower(strings.Join(words, "_"))
+	case NamingStyleKebab:
+		return strings.ToLower(strings.Join(words, "-"))
+	default:
+		return capitalizeFirstLetter(name)
+	}
+}
+
+// splitWords splits a camelCase, PascalCase, snake_case or kebab-case name into its words.
+// Acronyms stay together unless followed by a lowercase letter: "HTTPBasic" gives "HTTP" and "Basic".
+func splitWords(s string) []string {
+	var words []string
+	var current []rune
+	runes := []rune(s)
+	for i, r := range runes {
+		switch {
+		case unicode.IsUpper(r):
+			if len(current) > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				words = append(words, string(current))
+				current = nil
+			}
+			current = append(current, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			current = append(current, r)
+		default:
+			if len(current) > 0 {
+				words = append(words, string(current))
+				current = nil
+			}
+		}
+	}
+	if len(current) > 0 {
+		words = append(words, string(current))
+	}
+	return words
+}
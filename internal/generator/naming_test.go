@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lyeslabs/mcpgen/internal/converter"
+)
+
+func Test_splitWords(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"listTodos", []string{"list", "Todos"}},
+		{"GetTodoByID", []string{"Get", "Todo", "By", "ID"}},
+		{"HTTPBasic", []string{"HTTP", "Basic"}},
+		{"get_todo-by.id", []string{"get", "todo", "by", "id"}},
+		{"v2Items", []string{"v2", "Items"}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := splitWords(tt.input); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitWords(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_applyNamingStyle(t *testing.T) {
+	tests := []struct {
+		style string
+		want  string
+	}{
+		{"", "GetTodoByID"},
+		{NamingStylePascal, "GetTodoByID"},
+		{NamingStyleCamel, "getTodoById"},
+		{NamingStyleSnake, "get_todo_by_id"},
+		{NamingStyleKebab, "get-todo-by-id"},
+		{NamingStyleOriginal, "getTodoByID"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.style, func(t *testing.T) {
+			if got := applyNamingStyle("getTodoByID", tt.style); got != tt.want {
+				t.Errorf("applyNamingStyle(%q) = %q, want %q", tt.style, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerator_toolName(t *testing.T) {
+	g := &Generator{
+		Naming: NamingConfig{Style: NamingStyleSnake, Prefix: "todo_"},
+		ToolOverrides: map[string]ToolOverride{
+			"createTodo": {Name: "add_todo", Description: "Adds a todo"},
+		},
+	}
+
+	listTodos := converter.Tool{Name: "listTodos", Description: "List todos"}
+	if got := g.toolName(listTodos); got != "todo_list_todos" {
+		t.Errorf("toolName() = %q, want todo_list_todos", got)
+	}
+	if got := g.toolDescription(listTodos); got != "List todos" {
+		t.Errorf("toolDescription() = %q, want the converted description", got)
+	}
+
+	createTodo := converter.Tool{Name: "createTodo", Description: "Create a todo"}
+	if got := g.toolName(createTodo); got != "add_todo" {
+		t.Errorf("toolName() = %q, want the override add_todo", got)
+	}
+	if got := g.toolDescription(createTodo); got != "Adds a todo" {
+		t.Errorf("toolDescription() = %q, want the override", got)
+	}
+}
+
+func TestGenerator_checkToolNames(t *testing.T) {
+	config := &converter.MCPConfig{Tools: []converter.Tool{{Name: "listTodos"}, {Name: "list_todos"}}}
+
+	g := &Generator{}
+	if err := g.checkToolNames(config); err != nil {
+		t.Errorf("checkToolNames() error = %v, want nil for distinct names", err)
+	}
+
+	g.Naming.Style = NamingStyleSnake
+	err := g.checkToolNames(config)
+	if err == nil || !strings.Contains(err.Error(), `"list_todos"`) {
+		t.Errorf("checkToolNames() error = %v, want a collision on list_todos", err)
+	}
+}
@@ -0,0 +1,148 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/lyeslabs/mcpgen/internal/converter"
+)
+
+// Prompt generation modes
+const (
+	PromptModeNone = ""     // No prompts, the default
+	PromptModeTool = "tool" // One prompt per tool, named after the tool
+	PromptModeTag  = "tag"  // One prompt per operation tag covering the tools of the tag
+)
+
+// promptsFile holds the prompt registrations, its lower case name cannot clash with a tool file
+const promptsFile = "prompts.go"
+
+// untaggedPrompt names the prompt of the tools without tags in tag mode
+const untaggedPrompt = "default"
+
+// promptData is a prompt made of the prompt texts of one or more tools
+type promptData struct {
+	Name        string
+	Description string
+	Consts      []string // Prompt text constants of the tools, defined in the tool files
+}
+
+// promptIntro returns the beginning of the prompt of a tool, the input schema and response templates follow
+func (g *Generator) promptIntro(tool converter.Tool) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Calling the %s tool\n\n", g.toolName(tool))
+	if description := g.toolDescription(tool); description != "" {
+		fmt.Fprintf(&b, "%s\n\n", description)
+	}
+
+	b.WriteString("## Arguments\n\n")
+	if len(tool.Args) == 0 {
+		b.WriteString("The tool takes no arguments.\n\n")
+	}
+	for _, arg := range tool.Args {
+		details := []string{}
+		if arg.Schema != nil && len(arg.Schema.Types) > 0 {
+			details = append(details, strings.Join(arg.Schema.Types, " or "))
+		} else if arg.Schema != nil && arg.Schema.Ref != "" {
+			details = append(details, arg.Schema.Ref)
+		}
+		if arg.Required {
+			details = append(details, "required")
+		}
+		if arg.ParamName != "" && arg.ParamName != arg.Name {
+			details = append(details, fmt.Sprintf("%s %q", arg.Source, arg.ParamName))
+		} else {
+			details = append(details, arg.Source)
+		}
+		fmt.Fprintf(&b, "- `%s` (%s)", arg.Name, strings.Join(details, ", "))
+		if arg.Description != "" {
+			fmt.Fprintf(&b, ": %s", arg.Description)
+		}
+		b.WriteString("\n")
+	}
+	if len(tool.Args) > 0 {
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// toolPrompts returns the prompts to register for the configured mode
+func (g *Generator) toolPrompts(tools []converter.Tool) []promptData {
+	var prompts []promptData
+
+	switch g.PromptMode {
+	case PromptModeTool:
+		for _, tool := range tools {
+			name := g.toolName(tool)
+			prompts = append(prompts, promptData{
+				Name:        name,
+				Description: fmt.Sprintf("How to call the %s tool and interpret its responses", name),
+				Consts:      []string{capitalizeFirstLetter(tool.Name) + "Prompt"},
+			})
+		}
+	case PromptModeTag:
+		byTag := make(map[string][]string)
+		for _, tool := range tools {
+			tags := tool.Tags
+			if len(tags) == 0 {
+				tags = []string{untaggedPrompt}
+			}
+			for _, tag := range tags {
+				byTag[tag] = append(byTag[tag], capitalizeFirstLetter(tool.Name)+"Prompt")
+			}
+		}
+		for tag, consts := range byTag {
+			prompts = append(prompts, promptData{
+				Name:        tag,
+				Description: fmt.Sprintf("How to call the tools tagged %s and interpret their responses", tag),
+				Consts:      consts,
+			})
+		}
+		sort.Slice(prompts, func(i, j int) bool {
+			return prompts[i].Name < prompts[j].Name
+		})
+	}
+	return prompts
+}
+
+// GeneratePromptsFile writes the prompt registrations.
+// The file is removed when prompts are disabled since the prompt texts it uses are no longer generated.
+func (g *Generator) GeneratePromptsFile(config *converter.MCPConfig) error {
+	filePath := filepath.Join(g.outputDir, "mcptools", promptsFile)
+
+	prompts := g.toolPrompts(config.Tools)
+	if len(prompts) == 0 {
+		if err := os.Remove(filePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove %s: %w", promptsFile, err)
+		}
+		return nil
+	}
+
+	tmpl, err := parseTemplates("templates/prompts.templ")
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, prompts); err != nil {
+		return fmt.Errorf("failed to render prompts template: %w", err)
+	}
+
+	formattedCode, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format generated %s: %w", promptsFile, err)
+	}
+
+	if err := writeFileContent(g.outputDir+"/mcptools", promptsFile, func() ([]byte, error) {
+		return formattedCode, nil
+	}); err != nil {
+		return fmt.Errorf("failed to write %s: %w", promptsFile, err)
+	}
+	return nil
+}
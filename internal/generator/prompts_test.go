@@ -0,0 +1,92 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lyeslabs/mcpgen/internal/converter"
+)
+
+func TestGenerator_toolPrompts(t *testing.T) {
+	tools := []converter.Tool{
+		{Name: "createTodo", Tags: []string{"todos"}},
+		{Name: "health"},
+		{Name: "listTodos", Tags: []string{"todos", "read"}},
+	}
+
+	tests := []struct {
+		mode string
+		want []promptData
+	}{
+		{PromptModeNone, nil},
+		{PromptModeTool, []promptData{
+			{Name: "CreateTodo", Description: "How to call the CreateTodo tool and interpret its responses", Consts: []string{"CreateTodoPrompt"}},
+			{Name: "Health", Description: "How to call the Health tool and interpret its responses", Consts: []string{"HealthPrompt"}},
+			{Name: "ListTodos", Description: "How to call the ListTodos tool and interpret its responses", Consts: []string{"ListTodosPrompt"}},
+		}},
+		{PromptModeTag, []promptData{
+			{Name: "default", Description: "How to call the tools tagged default and interpret their responses", Consts: []string{"HealthPrompt"}},
+			{Name: "read", Description: "How to call the tools tagged read and interpret their responses", Consts: []string{"ListTodosPrompt"}},
+			{Name: "todos", Description: "How to call the tools tagged todos and interpret their responses", Consts: []string{"CreateTodoPrompt", "ListTodosPrompt"}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			g := &Generator{PromptMode: tt.mode}
+			if got := g.toolPrompts(tools); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toolPrompts() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerator_promptIntro(t *testing.T) {
+	g := &Generator{}
+	tool := converter.Tool{
+		Name:        "getTodo",
+		Description: "Gets a todo",
+		Args: []converter.Arg{
+			{Name: "todoId", Source: "path", Required: true, Description: "ID of the todo", Schema: &converter.Schema{Types: []string{"integer"}}},
+			{Name: "verbose", Source: "query", Schema: &converter.Schema{Types: []string{"boolean"}}},
+		},
+	}
+
+	want := "# Calling the GetTodo tool\n\nGets a todo\n\n## Arguments\n\n" +
+		"- `todoId` (integer, required, path): ID of the todo\n" +
+		"- `verbose` (boolean, query)\n\n"
+	if got := g.promptIntro(tool); got != want {
+		t.Errorf("promptIntro() = %q, want %q", got, want)
+	}
+}
+
+func TestGeneratePromptsFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	config := &converter.MCPConfig{
+		Tools: []converter.Tool{{Name: "createTodo", Tags: []string{"todos"}}, {Name: "listTodos", Tags: []string{"todos"}}},
+	}
+
+	g := &Generator{outputDir: tmpDir, PromptMode: PromptModeTag}
+	if err := g.GeneratePromptsFile(config); err != nil {
+		t.Fatalf("GeneratePromptsFile failed: %v", err)
+	}
+	filePath := filepath.Join(tmpDir, "mcptools", promptsFile)
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	if !strings.Contains(string(data), `CreateTodoPrompt+"\n\n"+ListTodosPrompt`) {
+		t.Errorf("Generated file missing the todos prompt:\n%s", data)
+	}
+
+	// Disabling prompts removes the file referencing the prompt texts
+	g.PromptMode = PromptModeNone
+	if err := g.GeneratePromptsFile(config); err != nil {
+		t.Fatalf("GeneratePromptsFile failed: %v", err)
+	}
+	if _, err := os.Stat(filePath); !os.IsNotExist(err) {
+		t.Errorf("expected %s to be removed, got %v", promptsFile, err)
+	}
+}
@@ -0,0 +1,152 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+
+	"github.com/lyeslabs/mcpgen/internal/converter"
+)
+
+// schemaResourcesFile holds the static schema resources, its lower case name cannot clash with a tool file
+const schemaResourcesFile = "schema_resources.go"
+
+// resourceTemplateData holds the data to pass to the template for a single resource
+type resourceTemplateData struct {
+	GoName      string
+	HandlerName string
+	Name        string
+	URI         string
+	Template    bool
+	Description string
+	MIMEType    string
+	RequestSpec requestSpecData
+	Proxy       bool
+}
+
+// newResourceTemplateData returns the template data of a resource read from a GET operation
+func (g *Generator) newResourceTemplateData(resource converter.Resource, schemes []converter.SecurityScheme) resourceTemplateData {
+	// Resources live next to the tools, the suffix keeps their names apart from the tool files
+	goName := capitalizeFirstLetter(resource.Name) + "Resource"
+	return resourceTemplateData{
+		GoName:      goName,
+		HandlerName: goName + "Handler",
+		Name:        g.toolName(*resource.Tool),
+		URI:         resource.URI,
+		Template:    resource.Template,
+		Description: g.toolDescription(*resource.Tool),
+		MIMEType:    resource.MIMEType,
+		RequestSpec: newRequestSpecData(*resource.Tool, schemes, goName+"Request", goName+" resource"),
+		Proxy:       g.HandlerMode == HandlerModeProxy,
+	}
+}
+
+// GenerateResourceFiles generates the resource files while preserving existing handler implementations.
+// Static schema resources are all written to a single file.
+func (g *Generator) GenerateResourceFiles(config *converter.MCPConfig) error {
+	tmpl, err := parseTemplates("templates/resource.templ", "templates/request.templ")
+	if err != nil {
+		return err
+	}
+
+	proxy := g.HandlerMode == HandlerModeProxy
+	helpersImportPath := ""
+	if proxy {
+		helpersImportPath, err = BuildHelpersImportPath(g.outputDir)
+		if err != nil {
+			return fmt.Errorf("failed to build helpers import path: %w", err)
+		}
+	}
+
+	var staticResources []converter.Resource
+	for _, resource := range config.Resources {
+		if resource.Tool == nil {
+			staticResources = append(staticResources, resource)
+			continue
+		}
+
+		data := g.newResourceTemplateData(resource, config.Server.SecuritySchemes)
+		err := g.writeHandlerFile(tmpl, data, handlerFile{
+			FileName:    data.GoName + ".go",
+			GoName:      data.GoName,
+			HandlerName: data.HandlerName,
+			RequestType: "mcp.ReadResourceRequest",
+			ResultType:  "[]mcp.ResourceContents",
+			Imports:     handlerImports(proxy, helpersImportPath),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to generate resource %s: %w", resource.Name, err)
+		}
+		if err := g.removeStaleToolFile(*resource.Tool); err != nil {
+			return err
+		}
+	}
+
+	if len(staticResources) > 0 {
+		if err := g.generateSchemaResources(staticResources); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// removeStaleToolFile removes the tool file generated for an operation before it became a resource.
+// A file whose handler was implemented is kept, with a warning, since removing it would lose the implementation.
+func (g *Generator) removeStaleToolFile(tool converter.Tool) error {
+	goName := capitalizeFirstLetter(tool.Name)
+	fileName := goName + ".go"
+	filePath := filepath.Join(g.outputDir, "mcptools", fileName)
+
+	content, err := os.ReadFile(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", fileName, err)
+	}
+
+	body, err := extractHandlerBody(string(content), goName+"Handler", "mcp.CallToolRequest", "*mcp.CallToolResult")
+	if err != nil || body == "" {
+		// Not a file generated for the tool
+		return err
+	}
+	if !isPlaceholderImplementation(body, goName) && !isProxyImplementation(body, goName+"Request") {
+		fmt.Fprintf(os.Stderr, "Warning: %s implements the %s tool, now read as a resource; remove it once its implementation has moved to %sResource.go\n",
+			fileName, tool.Name, goName)
+		return nil
+	}
+
+	if err := os.Remove(filePath); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", fileName, err)
+	}
+	return nil
+}
+
+// generateSchemaResources writes the static schema resources file
+func (g *Generator) generateSchemaResources(resources []converter.Resource) error {
+	tmpl, err := parseTemplates("templates/schemas.templ")
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, resources); err != nil {
+		return fmt.Errorf("failed to render schema resources template: %w", err)
+	}
+
+	formattedCode, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format generated %s: %w", schemaResourcesFile, err)
+	}
+
+	if err := writeFileContent(g.outputDir+"/mcptools", schemaResourcesFile, func() ([]byte, error) {
+		return formattedCode, nil
+	}); err != nil {
+		return fmt.Errorf("failed to write %s: %w", schemaResourcesFile, err)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lyeslabs/mcpgen/internal/converter"
+)
+
+func TestGenerateResourceFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	toolsDir := filepath.Join(tmpDir, "mcptools")
+
+	config := &converter.MCPConfig{
+		Resources: []converter.Resource{
+			{
+				Name:     "getTodo",
+				URI:      "openapi://todos/{todoId}",
+				Template: true,
+				MIMEType: "application/json",
+				Tool: &converter.Tool{
+					Name:        "getTodo",
+					Description: "Gets a todo",
+					Args:        []converter.Arg{{Name: "todoId", Source: "path", Required: true}},
+					RequestTemplate: converter.RequestTemplate{
+						URL:    "https://api.example.com/todos/{todoId}",
+						Path:   "/todos/{todoId}",
+						Method: "GET",
+					},
+				},
+			},
+			{
+				Name:     "Todo",
+				URI:      "openapi://schemas/Todo",
+				MIMEType: "application/json",
+				Text:     `{"type": "object"}`,
+			},
+		},
+	}
+
+	g := &Generator{PackageName: "mytools", outputDir: tmpDir}
+	if err := g.GenerateResourceFiles(config); err != nil {
+		t.Fatalf("GenerateResourceFiles failed: %v", err)
+	}
+
+	resourcePath := filepath.Join(toolsDir, "GetTodoResource.go")
+	data, err := os.ReadFile(resourcePath)
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	content := string(data)
+	for _, want := range []string{
+		"func NewGetTodoResourceMCPResourceTemplate() mcp.ResourceTemplate",
+		`"openapi://todos/{todoId}"`,
+		`mcp.WithTemplateDescription("Gets a todo")`,
+		"func GetTodoResourceHandler(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error)",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Generated file missing %q:\n%s", want, content)
+		}
+	}
+
+	schemas, err := os.ReadFile(filepath.Join(toolsDir, schemaResourcesFile))
+	if err != nil {
+		t.Fatalf("Failed to read schema resources: %v", err)
+	}
+	if !strings.Contains(string(schemas), `"openapi://schemas/Todo"`) || !strings.Contains(string(schemas), `"{\"type\": \"object\"}"`) {
+		t.Errorf("Schema resources missing the Todo schema:\n%s", schemas)
+	}
+
+	// An implemented handler survives regeneration in proxy mode
+	implemented := strings.Replace(content, `return nil, fmt.Errorf("%s not implemented", "GetTodoResource")`,
+		`return []mcp.ResourceContents{mcp.TextResourceContents{URI: request.Params.URI, Text: "todo"}}, nil`, 1)
+	if err := os.WriteFile(resourcePath, []byte(implemented), 0644); err != nil {
+		t.Fatalf("Failed to write implemented handler: %v", err)
+	}
+	g.HandlerMode = HandlerModeProxy
+	if err := g.GenerateResourceFiles(config); err != nil {
+		t.Fatalf("GenerateResourceFiles failed: %v", err)
+	}
+	data, err = os.ReadFile(resourcePath)
+	if err != nil {
+		t.Fatalf("Failed to read regenerated file: %v", err)
+	}
+	content = string(data)
+	if !strings.Contains(content, `Text: "todo"`) || strings.Contains(content, "mcputils.ProxyResource(") {
+		t.Errorf("Implemented handler was not preserved:\n%s", content)
+	}
+	if !strings.Contains(content, "var GetTodoResourceRequest = mcputils.RequestSpec{") {
+		t.Errorf("Regenerated file missing the request spec:\n%s", content)
+	}
+}
+
+func TestGenerateResourceFiles_StaleToolFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		handler  string
+		wantKept bool
+	}{
+		{name: "placeholder", handler: `return nil, fmt.Errorf("%s not implemented", "GetTodo")`},
+		{name: "proxy", handler: `return mcputils.Proxy(ctx, request, GetTodoRequest)`},
+		{name: "implemented", handler: `return mcp.NewToolResultText("todo"), nil`, wantKept: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			toolsDir := filepath.Join(tmpDir, "mcptools")
+			if err := os.MkdirAll(toolsDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			toolPath := filepath.Join(toolsDir, "GetTodo.go")
+			tool := "package mcptools\n\nfunc GetTodoHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {\n\t" + tt.handler + "\n}\n"
+			if err := os.WriteFile(toolPath, []byte(tool), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			config := &converter.MCPConfig{
+				Resources: []converter.Resource{{
+					Name: "getTodo",
+					URI:  "openapi://todos",
+					Tool: &converter.Tool{
+						Name:            "getTodo",
+						RequestTemplate: converter.RequestTemplate{URL: "https://api.example.com/todos", Path: "/todos", Method: "GET"},
+					},
+				}},
+			}
+			g := &Generator{PackageName: "mytools", outputDir: tmpDir}
+			if err := g.GenerateResourceFiles(config); err != nil {
+				t.Fatalf("GenerateResourceFiles failed: %v", err)
+			}
+
+			_, err := os.Stat(toolPath)
+			if kept := err == nil; kept != tt.wantKept {
+				t.Errorf("tool file kept = %v, want %v", kept, tt.wantKept)
+			}
+			if _, err := os.Stat(filepath.Join(toolsDir, "GetTodoResource.go")); err != nil {
+				t.Errorf("resource file not generated: %v", err)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package generator
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"go/ast"
 	"go/format"
@@ -9,6 +10,7 @@ import (
 	"go/printer"
 	"go/token"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"text/template"
@@ -18,115 +20,307 @@ import (
 
 // GenerateToolFiles generates individual tool files while preserving existing handler implementations
 func (g *Generator) GenerateToolFiles(config *converter.MCPConfig) error {
-	toolTemplateContent, err := templatesFS.ReadFile("templates/tool.templ")
+	tmpl, err := parseTemplates("templates/tool.templ", "templates/request.templ")
 	if err != nil {
-		return fmt.Errorf("failed to read tool template file: %w", err)
+		return err
 	}
 
-	tmpl, err := template.New("tool.templ").Parse(string(toolTemplateContent))
-	if err != nil {
-		return fmt.Errorf("failed to parse tool template: %w", err)
+	proxy := g.HandlerMode == HandlerModeProxy
+	helpersImportPath := ""
+	if proxy {
+		helpersImportPath, err = BuildHelpersImportPath(g.outputDir)
+		if err != nil {
+			return fmt.Errorf("failed to build helpers import path: %w", err)
+		}
 	}
 
 	for _, tool := range config.Tools {
 		capitalizedName := capitalizeFirstLetter(tool.Name)
 		data := struct {
 			ToolTemplateData
-			URL     string
-			Method  string
-			Headers []converter.Header
+			ArgsTypes       string
+			Output          *converter.ToolOutput // Nil when the tool has no output schema
+			Simplifications []string              // Simplifications applied to the input schema to fit the schema budget
+			RequestSpec     requestSpecData
+			Annotations     converter.ToolAnnotations
+			PromptIntro     string // Empty when prompts are disabled
+			Proxy           bool
 		}{
 			ToolTemplateData: ToolTemplateData{
-				ToolNameOriginal:      capitalizedName,
+				ToolNameOriginal:      g.toolName(tool),
 				ToolNameGo:            capitalizedName,
 				ToolHandlerName:       capitalizedName + "Handler",
-				ToolDescription:       tool.Description,
+				ToolDescription:       g.toolDescription(tool),
 				RawInputSchema:        tool.RawInputSchema,
 				ResponseTemplate:      tool.Responses,
-				InputSchemaConst:      fmt.Sprintf("%sInputSchema", tool.Name),
+				InputSchemaConst:      capitalizedName + "InputSchema",
 				ResponseTemplateConst: fmt.Sprintf("%sResponseTemplate", tool.Name),
 			},
-			URL:     tool.RequestTemplate.URL,
-			Method:  tool.RequestTemplate.Method,
-			Headers: tool.RequestTemplate.Headers,
+			ArgsTypes:       argsTypes(capitalizedName, tool.Args, tool.Definitions),
+			Output:          tool.Output,
+			Simplifications: tool.SchemaSimplifications,
+			RequestSpec:     newRequestSpecData(tool, config.Server.SecuritySchemes, capitalizedName+"Request", capitalizedName+" tool"),
+			Annotations:     tool.Annotations,
+			Proxy:           proxy,
+		}
+		if g.PromptMode != PromptModeNone {
+			data.PromptIntro = g.promptIntro(tool)
 		}
 
-		outputFileName := capitalizedName + ".go"
-		outputFilePath := filepath.Join(g.outputDir+"/mcptools", outputFileName)
+		err := g.writeHandlerFile(tmpl, data, handlerFile{
+			FileName:    capitalizedName + ".go",
+			GoName:      capitalizedName,
+			HandlerName: data.ToolHandlerName,
+			RequestType: "mcp.CallToolRequest",
+			ResultType:  "*mcp.CallToolResult",
+			Imports:     handlerImports(proxy, helpersImportPath),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to generate tool %s: %w", tool.Name, err)
+		}
+	}
 
-		// Check if file already exists and extract handler implementation if it does
-		existingImplementation := ""
-		existingImports := []string{}
+	return nil
+}
 
-		if _, err := os.Stat(outputFilePath); err == nil {
-			existingContent, err := os.ReadFile(outputFilePath)
-			if err == nil {
-				existingImplementation, err = extractHandlerImplementation(string(existingContent), data.ToolHandlerName)
-				if err != nil {
-					return err
-				}
-				// Extract existing imports
-				existingImports = extractImports(string(existingContent))
+// handlerFile describes a generated file of the mcptools package whose handler implementation is kept on regeneration
+type handlerFile struct {
+	FileName    string
+	GoName      string // Name reported by the placeholder implementation
+	HandlerName string
+	RequestType string // Type of the handler request parameter, e.g. "mcp.CallToolRequest"
+	ResultType  string // Type of the first handler result, e.g. "*mcp.CallToolResult"
+	Imports     []string
+}
+
+// writeHandlerFile renders a template into the mcptools package.
+// An implemented handler and its imports found in the existing file replace the generated ones.
+func (g *Generator) writeHandlerFile(tmpl *template.Template, data any, file handlerFile) error {
+	outputFilePath := filepath.Join(g.outputDir+"/mcptools", file.FileName)
+
+	// Check if file already exists and extract handler implementation if it does
+	existingImplementation := ""
+	existingImports := []string{}
+
+	if _, err := os.Stat(outputFilePath); err == nil {
+		existingContent, err := os.ReadFile(outputFilePath)
+		if err == nil {
+			existingImplementation, err = extractHandlerBody(string(existingContent), file.HandlerName, file.RequestType, file.ResultType)
+			if err != nil {
+				return err
 			}
+			// An untouched placeholder is regenerated so that switching modes takes effect
+			if isPlaceholderImplementation(existingImplementation, file.GoName) {
+				existingImplementation = ""
+			}
+			// Extract existing imports
+			existingImports = extractImports(string(existingContent))
 		}
+	}
 
-		// Generate code for this tool
-		var toolBuf bytes.Buffer
+	// Generate code for this file
+	var buf bytes.Buffer
 
-		// Write package declaration
-		fmt.Fprintf(&toolBuf, "package mcptools\n\n")
+	// Write package declaration
+	fmt.Fprintf(&buf, "package mcptools\n\n")
 
-		// Merge imports
-		requiredImports := []string{
-			"context",
-			"fmt",
-			"github.com/mark3labs/mcp-go/mcp",
-		}
+	fmt.Fprintf(&buf, "import (\n")
+	for _, imp := range mergeImports(existingImports, file.Imports) {
+		fmt.Fprintf(&buf, "\t%s\n", imp)
+	}
+	fmt.Fprintf(&buf, ")\n\n")
 
-		if len(existingImports) > 0 {
-			fmt.Fprintf(&toolBuf, "import (\n")
-			for _, imp := range existingImports {
-				fmt.Fprintf(&toolBuf, "\t%s\n", imp)
-			}
-			fmt.Fprintf(&toolBuf, ")\n\n")
+	// Execute template to get the boilerplate
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return err
+	}
+
+	// If we have an existing implementation, replace the default one
+	if existingImplementation != "" {
+		content := replaceHandlerImplementation(buf.String(), file.HandlerName, existingImplementation)
+		buf.Reset()
+		buf.WriteString(content)
+	}
+
+	// Drop the generated imports that neither the template nor the preserved handler use,
+	// the imports added by users are kept as they are
+	prunedCode, err := removeUnusedImports(buf.Bytes(), file.Imports)
+	if err != nil {
+		return fmt.Errorf("failed to prune imports for %s: %w", file.FileName, err)
+	}
+
+	// Format the generated code
+	formattedCode, err := format.Source(prunedCode)
+	if err != nil {
+		return fmt.Errorf("failed to format generated code for %s: %w", file.FileName, err)
+	}
+
+	err = writeFileContent(g.outputDir+"/mcptools", file.FileName, func() ([]byte, error) {
+		return formattedCode, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", file.FileName, err)
+	}
+	return nil
+}
+
+// handlerImports returns the imports needed by the generated handler files
+func handlerImports(proxy bool, helpersImportPath string) []string {
+	imports := []string{
+		`"context"`,
+		`"fmt"`,
+		`"github.com/mark3labs/mcp-go/mcp"`,
+	}
+	if proxy {
+		// time is dropped with the other unused imports when no request has a policy of its own
+		imports = append(imports, `"time"`, fmt.Sprintf("mcputils %q", helpersImportPath))
+	}
+	return imports
+}
+
+// parseTemplates parses a template together with the partial templates it uses
+func parseTemplates(name string, partials ...string) (*template.Template, error) {
+	var tmpl *template.Template
+	for _, file := range append([]string{name}, partials...) {
+		content, err := templatesFS.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template file %s: %w", file, err)
+		}
+		if tmpl == nil {
+			tmpl = template.New(path.Base(file))
 		} else {
-			fmt.Fprintf(&toolBuf, "import (\n")
-			for _, imp := range requiredImports {
-				fmt.Fprintf(&toolBuf, "\t\"%s\"\n", imp)
-			}
-			fmt.Fprintf(&toolBuf, ")\n\n")
+			tmpl = tmpl.New(path.Base(file))
 		}
-
-		// Execute template to get the boilerplate
-		if err := tmpl.Execute(&toolBuf, data); err != nil {
-			return fmt.Errorf("failed to render template for tool %s: %w", tool.Name, err)
+		if _, err := tmpl.Parse(string(content)); err != nil {
+			return nil, fmt.Errorf("failed to parse template %s: %w", file, err)
 		}
+	}
+	return tmpl.Lookup(path.Base(name)), nil
+}
+
+// requestSpecData holds the upstream HTTP request rendered as a mcputils.RequestSpec for proxy handlers
+type requestSpecData struct {
+	Var          string // Name of the generated variable
+	Owner        string // Tool or resource the request belongs to, for the doc comment
+	BaseURL      string
+	Path         string
+	Method       string
+	Headers      []converter.Header
+	Args         []converter.Arg
+	HiddenArgs   []hiddenArgData
+	Security     []securitySchemeData
+	Output       *converter.ToolOutput
+	Encoding     map[string]converter.BodyEncoding
+	BodyRequired bool              // A flattened body is sent even when none of its properties is set
+	Responses    map[int]string    // Documented content type by status code
+	Errors       map[string]string // Documented meaning by error code or range
+	Policy       string            // Fields of the mcputils.Policy literal, empty for the default policy
+	Pagination   *converter.Pagination
+}
+
+// newRequestSpecData collects the upstream request of a converted operation
+func newRequestSpecData(tool converter.Tool, schemes []converter.SecurityScheme, varName, owner string) requestSpecData {
+	return requestSpecData{
+		Var:          varName,
+		Owner:        owner,
+		BaseURL:      strings.TrimSuffix(tool.RequestTemplate.URL, tool.RequestTemplate.Path),
+		Path:         tool.RequestTemplate.Path,
+		Method:       tool.RequestTemplate.Method,
+		Headers:      tool.RequestTemplate.Headers,
+		Args:         tool.Args,
+		HiddenArgs:   hiddenArgsWithValue(tool.HiddenArgs),
+		Security:     resolveSecuritySchemes(tool.RequestTemplate.Security, schemes),
+		Output:       tool.Output,
+		Encoding:     tool.RequestTemplate.BodyEncodings,
+		BodyRequired: tool.RequestTemplate.BodyRequired,
+		Responses:    responseTypes(tool.Responses),
+		Errors:       tool.Errors,
+		Policy:       policyFields(tool.Policy),
+		Pagination:   tool.Pagination,
+	}
+}
 
-		// If we have an existing implementation, replace the default one
-		if existingImplementation != "" {
-			toolContent := toolBuf.String()
-			toolContent = replaceHandlerImplementation(toolContent, data.ToolHandlerName, existingImplementation)
-			toolBuf.Reset()
-			toolBuf.WriteString(toolContent)
+// responseTypes returns the first documented content type of each response status code,
+// keyed by status code, by the first digit of ranges such as 2XX, and by 0 for default
+func responseTypes(responses []converter.ResponseTemplate) map[int]string {
+	var types map[int]string
+	for _, response := range responses {
+		key := response.StatusCode
+		if key == 0 {
+			code := strings.ToUpper(response.Code)
+			if len(code) == 3 && strings.HasSuffix(code, "XX") && code[0] >= '1' && code[0] <= '5' {
+				key = int(code[0] - '0')
+			} else if code != "DEFAULT" {
+				continue
+			}
+		}
+		if _, ok := types[key]; ok {
+			continue
+		}
+		if types == nil {
+			types = make(map[int]string)
 		}
+		types[key] = response.ContentType
+	}
+	return types
+}
 
-		// Format the generated code
-		formattedCode, err := format.Source(toolBuf.Bytes())
+// hiddenArgData is an argument hidden from clients that the proxy sends with a fixed value
+type hiddenArgData struct {
+	Name     string
+	In       string
+	Property string // Body property filled by a flattened body argument
+	Style    string
+	Explode  bool
+	Value    string // JSON encoded
+}
+
+// hiddenArgsWithValue keeps the hidden arguments with a default value, the only ones the proxy can send
+func hiddenArgsWithValue(args []converter.Arg) []hiddenArgData {
+	var hidden []hiddenArgData
+	for _, arg := range args {
+		if arg.Schema == nil || arg.Schema.Default == nil {
+			continue
+		}
+		value, err := json.Marshal(arg.Schema.Default)
 		if err != nil {
-			return fmt.Errorf("failed to format generated code for %s: %w", outputFileName, err)
+			continue
 		}
+		hidden = append(hidden, hiddenArgData{Name: arg.Name, In: arg.Source, Property: arg.BodyProperty, Style: arg.Style, Explode: arg.Explode, Value: string(value)})
+	}
+	return hidden
+}
 
-		err = writeFileContent(g.outputDir+"/mcptools", outputFileName, func() ([]byte, error) {
-			return formattedCode, nil
-		})
+// securitySchemeData holds a security scheme used by a tool and the environment variable of its credential
+type securitySchemeData struct {
+	converter.SecurityScheme
+	EnvVar      string
+	Alternative int
+}
 
-		if err != nil {
-			return fmt.Errorf("failed to write %s: %w", outputFileName, err)
+// resolveSecuritySchemes looks up the security schemes required by a tool, the converter rejects unknown IDs
+func resolveSecuritySchemes(requirements []converter.ToolSecurityRequirement, schemes []converter.SecurityScheme) []securitySchemeData {
+	var resolved []securitySchemeData
+	for _, requirement := range requirements {
+		for _, scheme := range schemes {
+			if scheme.ID == requirement.ID {
+				resolved = append(resolved, securitySchemeData{
+					SecurityScheme: scheme,
+					EnvVar:         toEnvVarName(scheme.ID),
+					Alternative:    requirement.Alternative,
+				})
+				break
+			}
 		}
 	}
+	return resolved
+}
 
-	return nil
+// toEnvVarName converts an identifier such as "ApiKeyAuth" or "petstore_auth" to "API_KEY_AUTH" or "PETSTORE_AUTH"
+func toEnvVarName(s string) string {
+	return strings.ToUpper(strings.Join(splitWords(s), "_"))
 }
+
 func capitalizeFirstLetter(s string) string {
 	if len(s) == 0 {
 		return s
@@ -136,6 +330,119 @@ func capitalizeFirstLetter(s string) string {
 	return string(runes)
 }
 
+// mergeImports appends the required imports missing from the existing ones
+func mergeImports(existing, required []string) []string {
+	merged := append([]string{}, existing...)
+	for _, req := range required {
+		found := false
+		for _, imp := range existing {
+			if importPath(imp) == importPath(req) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, req)
+		}
+	}
+	return merged
+}
+
+// importPath returns the quoted path of an import line, without its name
+func importPath(importLine string) string {
+	fields := strings.Fields(importLine)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[len(fields)-1]
+}
+
+// removeUnusedImports removes the given imports when they are not referenced in the file.
+// Only imports whose package name is the last element of their path, or that are named, can be given.
+func removeUnusedImports(src []byte, imports []string) ([]byte, error) {
+	prunable := make(map[string]bool, len(imports))
+	for _, imp := range imports {
+		prunable[importPath(imp)] = true
+	}
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	used := make(map[string]bool)
+	ast.Inspect(f, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if ident, ok := sel.X.(*ast.Ident); ok {
+				used[ident.Name] = true
+			}
+		}
+		return true
+	})
+
+	// Remove the lines of unused import specs, keeping the rest of the file untouched
+	var unused [][2]int
+	for _, imp := range f.Imports {
+		if !prunable[imp.Path.Value] {
+			continue
+		}
+		name := path.Base(strings.Trim(imp.Path.Value, `"`))
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		if name == "_" || name == "." || used[name] {
+			continue
+		}
+		start := fset.Position(imp.Pos()).Offset
+		end := fset.Position(imp.End()).Offset
+		for start > 0 && src[start-1] != '\n' {
+			start--
+		}
+		if next := bytes.IndexByte(src[end:], '\n'); next != -1 {
+			end += next + 1
+		} else {
+			end = len(src)
+		}
+		unused = append(unused, [2]int{start, end})
+	}
+	if len(unused) == 0 {
+		return src, nil
+	}
+
+	var buf bytes.Buffer
+	last := 0
+	for _, r := range unused {
+		buf.Write(src[last:r[0]])
+		last = r[1]
+	}
+	buf.Write(src[last:])
+	return buf.Bytes(), nil
+}
+
+// isPlaceholderImplementation reports whether a handler body is the untouched generated stub
+func isPlaceholderImplementation(body, toolName string) bool {
+	placeholder := fmt.Sprintf(`return nil, fmt.Errorf("%%s not implemented", %q)`, toolName)
+	statements := 0
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line == "{" || line == "}" || strings.HasPrefix(line, "//") {
+			continue
+		}
+		if line != placeholder {
+			return false
+		}
+		statements++
+	}
+	return statements == 1
+}
+
+// isProxyImplementation reports whether a handler body is the untouched generated proxy call
+func isProxyImplementation(body, requestVar string) bool {
+	body = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(body), "{"), "}"))
+	return body == fmt.Sprintf("return mcputils.Proxy(ctx, request, %s)", requestVar)
+}
+
 func extractImports(fileContent string) []string {
 	fset := token.NewFileSet()
 	f, err := parser.ParseFile(fset, "", fileContent, parser.ImportsOnly)
@@ -155,6 +462,11 @@ func extractImports(fileContent string) []string {
 }
 
 func extractHandlerImplementation(fileContent, handlerName string) (string, error) {
+	return extractHandlerBody(fileContent, handlerName, "mcp.CallToolRequest", "*mcp.CallToolResult")
+}
+
+// extractHandlerBody returns the body of the handler with the given request and result types
+func extractHandlerBody(fileContent, handlerName, requestType, resultType string) (string, error) {
 	fset := token.NewFileSet()
 	f, err := parser.ParseFile(fset, "", fileContent, parser.ParseComments)
 	if err != nil {
@@ -168,17 +480,17 @@ func extractHandlerImplementation(fileContent, handlerName string) (string, erro
 		if !ok || fn.Name.Name != handlerName {
 			continue
 		}
-		// Check signature: (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+		// Check signature: (ctx context.Context, request <requestType>) (<resultType>, error)
 		if len(fn.Type.Params.List) != 2 || len(fn.Type.Results.List) != 2 {
 			continue
 		}
 		param2 := fn.Type.Params.List[1]
 		result1 := fn.Type.Results.List[0]
 
-		if exprToString(param2.Type) != "mcp.CallToolRequest" {
+		if exprToString(param2.Type) != requestType {
 			continue
 		}
-		if exprToString(result1.Type) != "*mcp.CallToolResult" {
+		if exprToString(result1.Type) != resultType {
 			continue
 		}
 
@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/lyeslabs/mcpgen/internal/converter"
 )
@@ -438,6 +439,45 @@ func TestGenerateToolFiles(t *testing.T) {
 	}
 }
 
+func TestGenerateToolFilesAnnotations(t *testing.T) {
+	tmpDir := t.TempDir()
+	readOnly := true
+	config := &converter.MCPConfig{
+		Tools: []converter.Tool{
+			{
+				Name:           "listTodos",
+				Description:    "Lists todos",
+				RawInputSchema: `{"type":"object"}`,
+				Annotations:    converter.ToolAnnotations{Title: "List todos", ReadOnlyHint: &readOnly},
+				RequestTemplate: converter.RequestTemplate{
+					URL:    "/todos",
+					Method: "GET",
+				},
+			},
+		},
+	}
+
+	g := &Generator{PackageName: "mytools", outputDir: tmpDir}
+	if err := g.GenerateToolFiles(config); err != nil {
+		t.Fatalf("GenerateToolFiles failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "mcptools", "ListTodos.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	content := string(data)
+	for _, want := range []string{`Title:        "List todos"`, "ReadOnlyHint: mcp.ToBoolPtr(true)"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Generated file missing %q:\n%s", want, content)
+		}
+	}
+	// Hints that were not derived are left to the client defaults
+	if strings.Contains(content, "DestructiveHint") {
+		t.Errorf("Generated file should not set DestructiveHint:\n%s", content)
+	}
+}
+
 func TestGenerateToolFilesWithHandlerBodyImplemented(t *testing.T) {
 	tmpDir := t.TempDir()
 	toolsDir := filepath.Join(tmpDir, "mcptools")
@@ -491,12 +531,14 @@ func TestGenerateToolFilesWithHandlerBodyImplemented(t *testing.T) {
 	customHandler := `
 func EchoHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	// CUSTOM USER LOGIC
-	return &mcp.CallToolResult{Payload: []byte("custom")}, nil
+	payload, _ := yaml.Marshal("custom")
+	return &mcp.CallToolResult{Payload: payload}, nil
 }
 `
 
-	// Replace the handler body in the file
+	// Replace the handler body in the file, with an import whose package name differs from its path
 	modified := replaceHandlerImplementation(string(origContent), "EchoHandler", customHandler)
+	modified = strings.Replace(modified, "import (\n", "import (\n\t\"gopkg.in/yaml.v3\"\n", 1)
 
 	if err := os.WriteFile(echoFile, []byte(modified), 0644); err != nil {
 		t.Fatalf("Failed to write custom Echo.go: %v", err)
@@ -516,4 +558,300 @@ func EchoHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToo
 	if !strings.Contains(content, "// CUSTOM USER LOGIC") {
 		t.Errorf("Custom handler implementation was not preserved in Echo.go")
 	}
+	if !strings.Contains(content, `"gopkg.in/yaml.v3"`) {
+		t.Errorf("Import added to the custom handler was not preserved in Echo.go:\n%s", content)
+	}
+}
+
+func Test_mergeImports(t *testing.T) {
+	existing := []string{`"context"`, `myfmt "fmt"`}
+	required := []string{`"context"`, `"fmt"`, `"github.com/mark3labs/mcp-go/mcp"`}
+
+	got := mergeImports(existing, required)
+	want := []string{`"context"`, `myfmt "fmt"`, `"github.com/mark3labs/mcp-go/mcp"`}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeImports() = %#v, want %#v", got, want)
+	}
+}
+
+func Test_removeUnusedImports(t *testing.T) {
+	const src = `package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+	_ "net/http/pprof"
+	mcputils "example.com/app/helpers"
+	"gopkg.in/yaml.v3"
+)
+
+func Handler(ctx context.Context) error {
+	_, err := yaml.Marshal(nil)
+	return mcputils.Do(ctx, err)
+}
+`
+	generated := []string{`"context"`, `"fmt"`, `"time"`, `mcputils "example.com/app/helpers"`}
+	out, err := removeUnusedImports([]byte(src), generated)
+	if err != nil {
+		t.Fatalf("removeUnusedImports failed: %v", err)
+	}
+	content := string(out)
+	if strings.Contains(content, `"fmt"`) || strings.Contains(content, `"time"`) {
+		t.Errorf("expected unused fmt and time imports to be removed, got:\n%s", content)
+	}
+	// Imports added by users are never removed, whatever the name of their package
+	for _, imp := range []string{`"context"`, `_ "net/http/pprof"`, `mcputils "example.com/app/helpers"`, `"gopkg.in/yaml.v3"`} {
+		if !strings.Contains(content, imp) {
+			t.Errorf("expected import %s to be kept, got:\n%s", imp, content)
+		}
+	}
+
+	if _, err := removeUnusedImports([]byte("not a go file"), generated); err == nil {
+		t.Error("expected error for invalid Go code")
+	}
+}
+
+func Test_isPlaceholderImplementation(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want bool
+	}{
+		{
+			name: "generated stub",
+			body: `{
+	// IMPORTANT: Replace the following placeholder implementation with your actual logic.
+	return nil, fmt.Errorf("%s not implemented", "Echo")
+}`,
+			want: true,
+		},
+		{
+			name: "stub of another tool",
+			body: `{
+	return nil, fmt.Errorf("%s not implemented", "Reverse")
+}`,
+			want: false,
+		},
+		{
+			name: "custom logic before placeholder",
+			body: `{
+	log.Println("called")
+	return nil, fmt.Errorf("%s not implemented", "Echo")
+}`,
+			want: false,
+		},
+		{
+			name: "empty body",
+			body: "",
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isPlaceholderImplementation(tt.body, "Echo"); got != tt.want {
+				t.Errorf("isPlaceholderImplementation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateToolFilesProxyMode(t *testing.T) {
+	tmpDir := t.TempDir()
+	toolsDir := filepath.Join(tmpDir, "mcptools")
+
+	config := &converter.MCPConfig{
+		Tools: []converter.Tool{
+			{
+				Name:           "getTodo",
+				MCPName:        "fetch_todo",
+				Description:    "Gets a todo",
+				RawInputSchema: `{"type":"object","properties":{"id":{"type":"string"}}}`,
+				Args: []converter.Arg{
+					{Name: "id", Source: "path", Required: true},
+					{Name: "verbose", Source: "query"},
+				},
+				HiddenArgs: []converter.Arg{
+					{Name: "tenant", Source: "header", Schema: &converter.Schema{Default: "acme"}},
+					{Name: "X-Trace", Source: "header", Schema: &converter.Schema{}},
+				},
+				RequestTemplate: converter.RequestTemplate{
+					URL:          "https://api.example.com/v1/todos/{id}",
+					Path:         "/todos/{id}",
+					Method:       "GET",
+					Security:     []converter.ToolSecurityRequirement{{ID: "bearerAuth"}},
+					BodyRequired: true,
+				},
+				Output: &converter.ToolOutput{StatusCode: 200, ContentType: "application/json", RawSchema: `{"type":"object"}`},
+				Responses: []converter.ResponseTemplate{
+					{StatusCode: 200, ContentType: "application/json", Suffix: "A"},
+					{StatusCode: 200, ContentType: "text/plain", Suffix: "B"},
+					{StatusCode: 404, ContentType: "application/problem+json", Suffix: "C"},
+				},
+				Errors: map[string]string{"404": "Todo not found", "5XX": "Server error"},
+				Policy: converter.RequestPolicy{Timeout: 90 * time.Second, MaxAttempts: 1},
+			},
+		},
+		Server: converter.ServerConfig{
+			SecuritySchemes: []converter.SecurityScheme{
+				{ID: "bearerAuth", Type: "http", Scheme: "bearer"},
+			},
+		},
+	}
+
+	g := &Generator{
+		PackageName: "mytools",
+		outputDir:   tmpDir,
+	}
+
+	// 1. Generate stub handlers first
+	if err := g.GenerateToolFiles(config); err != nil {
+		t.Fatalf("GenerateToolFiles failed: %v", err)
+	}
+
+	// 2. Regenerate in proxy mode, the untouched stub must be replaced
+	g.HandlerMode = HandlerModeProxy
+	if err := g.GenerateToolFiles(config); err != nil {
+		t.Fatalf("GenerateToolFiles (proxy) failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(toolsDir, "GetTodo.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	content := string(data)
+
+	expected := []string{
+		"var GetTodoRequest = mcputils.RequestSpec{",
+		`BaseURL: "https://api.example.com/v1",`,
+		`Path:    "/todos/{id}",`,
+		`{Name: "id", In: "path", Required: true},`,
+		`{Name: "verbose", In: "query", Required: false},`,
+		`{Name: "tenant", In: "header", Value: "\"acme\""},`,
+		`"fetch_todo",`,
+		`{ID: "bearerAuth", Type: "http", Scheme: "bearer", In: "", Name: "", EnvVar: "BEARER_AUTH", DefaultCredential: ""},`,
+		"return mcputils.Proxy(ctx, request, GetTodoRequest)",
+		"const GetTodoOutputSchema = `{\"type\":\"object\"}`",
+		"tool.RawOutputSchema = []byte(GetTodoOutputSchema)",
+		`&mcputils.OutputSpec{StatusCode: 200, Property: ""},`,
+		"BodyRequired: true,",
+		// The first documented content type of each status code
+		"Responses: map[int]string{\n\t\t200: \"application/json\",\n\t\t404: \"application/problem+json\",\n\t},",
+		"Errors: map[string]string{\n\t\t\"404\": \"Todo not found\",\n\t\t\"5XX\": \"Server error\",\n\t},",
+		"Policy: mcputils.Policy{Timeout: 90 * time.Second, MaxAttempts: 1},",
+		`"time"`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(content, want) {
+			t.Errorf("Generated proxy file missing %q, got:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "X-Trace") {
+		t.Errorf("Expected hidden argument without a default to be left out, got:\n%s", content)
+	}
+	if strings.Contains(content, "not implemented") {
+		t.Errorf("Expected stub implementation to be replaced, got:\n%s", content)
+	}
+	// fmt stays imported for the argument parser even though the proxy handler does not use it
+	if !strings.Contains(content, "func ParseGetTodoArgs(request mcp.CallToolRequest) (*GetTodoArgs, error)") {
+		t.Errorf("Expected the typed argument parser, got:\n%s", content)
+	}
+}
+
+func TestGenerateToolFilesPagination(t *testing.T) {
+	tmpDir := t.TempDir()
+	config := &converter.MCPConfig{
+		Tools: []converter.Tool{
+			{
+				Name:           "listTodos",
+				Description:    "Lists todos",
+				RawInputSchema: `{"type":"object"}`,
+				Args: []converter.Arg{
+					{Name: "maxPages", ParamName: "maxPages", Source: "pagination"},
+					{Name: "page", Source: "query", Style: "form", Explode: true},
+				},
+				RequestTemplate: converter.RequestTemplate{
+					URL:    "https://api.example.com/todos",
+					Path:   "/todos",
+					Method: "GET",
+				},
+				Pagination: &converter.Pagination{Style: converter.PaginationPage, Param: "page", Items: "data"},
+			},
+		},
+	}
+
+	g := &Generator{PackageName: "mytools", HandlerMode: HandlerModeProxy, outputDir: tmpDir}
+	if err := g.GenerateToolFiles(config); err != nil {
+		t.Fatalf("GenerateToolFiles failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "mcptools", "ListTodos.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	content := string(data)
+	for _, want := range []string{
+		`{Name: "maxPages", In: "pagination", Required: false},`,
+		// The first page number is left out when it is 0
+		`Pagination: &mcputils.PaginationSpec{Style: "page", Param: "page", Items: "data"},`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Generated file missing %q:\n%s", want, content)
+		}
+	}
+}
+
+func Test_toEnvVarName(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"ApiKeyAuth", "API_KEY_AUTH"},
+		{"bearerAuth", "BEARER_AUTH"},
+		{"petstore_auth", "PETSTORE_AUTH"},
+		{"OAuth2", "O_AUTH2"},
+		{"HTTPBasic", "HTTP_BASIC"},
+		{"api-key", "API_KEY"},
+	}
+
+	for _, tt := range tests {
+		if got := toEnvVarName(tt.in); got != tt.want {
+			t.Errorf("toEnvVarName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func Test_resolveSecuritySchemes(t *testing.T) {
+	schemes := []converter.SecurityScheme{
+		{ID: "ApiKeyAuth", Type: "apiKey", In: "header", Name: "X-API-KEY"},
+		{ID: "bearerAuth", Type: "http", Scheme: "bearer"},
+	}
+	requirements := []converter.ToolSecurityRequirement{{ID: "bearerAuth"}, {ID: "unknown"}, {ID: "ApiKeyAuth", Alternative: 1}}
+
+	got := resolveSecuritySchemes(requirements, schemes)
+	want := []securitySchemeData{
+		{SecurityScheme: schemes[1], EnvVar: "BEARER_AUTH"},
+		{SecurityScheme: schemes[0], EnvVar: "API_KEY_AUTH", Alternative: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("resolveSecuritySchemes() = %+v, want %+v", got, want)
+	}
+}
+
+func Test_responseTypes(t *testing.T) {
+	responses := []converter.ResponseTemplate{
+		{StatusCode: 200, Code: "200", ContentType: "application/json"},
+		{StatusCode: 200, Code: "200", ContentType: "text/plain"},
+		{Code: "2XX", ContentType: "application/pdf"},
+		{Code: "4xx", ContentType: "application/problem+json"},
+		{Code: "5XX", ContentType: "text/html"},
+		{Code: "default", ContentType: "application/xml"},
+	}
+
+	// Error ranges keep their own keys instead of becoming the fallback of every status code
+	want := map[int]string{200: "application/json", 2: "application/pdf", 4: "application/problem+json", 5: "text/html", 0: "application/xml"}
+	if got := responseTypes(responses); !reflect.DeepEqual(got, want) {
+		t.Errorf("responseTypes() = %v, want %v", got, want)
+	}
 }
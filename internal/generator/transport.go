@@ -0,0 +1,176 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lyeslabs/mcpgen/internal/converter"
+)
+
+// Defaults of the upstream requests sent by the generated server
+const (
+	DefaultTimeout        = 30 * time.Second
+	DefaultMaxAttempts    = 3
+	DefaultInitialBackoff = 500 * time.Millisecond
+	DefaultMaxBackoff     = 30 * time.Second
+)
+
+// TransportConfig sets the timeout, retries and rate limit of the upstream requests sent by the generated server.
+// Durations are written like 30s or 1m30s.
+type TransportConfig struct {
+	Timeout   string          `json:"timeout,omitempty"`
+	Retry     RetryConfig     `json:"retry,omitempty"`
+	RateLimit RateLimitConfig `json:"rateLimit,omitempty"`
+}
+
+// RetryConfig sets how failed upstream requests are retried
+type RetryConfig struct {
+	MaxAttempts    int    `json:"maxAttempts,omitempty"` // Attempts including the first one, 1 disables retries
+	InitialBackoff string `json:"initialBackoff,omitempty"`
+	MaxBackoff     string `json:"maxBackoff,omitempty"`
+}
+
+// RateLimitConfig caps the requests sent to the upstream API, shared by every tool
+type RateLimitConfig struct {
+	RequestsPerSecond float64 `json:"requestsPerSecond,omitempty"`
+	Burst             int     `json:"burst,omitempty"` // Requests sent at once after a quiet period, 1 by default
+}
+
+// requestPolicy parses a configured timeout and retry policy, unset values are left to zero
+func requestPolicy(timeout string, retry RetryConfig) (converter.RequestPolicy, error) {
+	var policy converter.RequestPolicy
+	if retry.MaxAttempts < 0 {
+		return policy, fmt.Errorf("maxAttempts must not be negative, got %d", retry.MaxAttempts)
+	}
+	policy.MaxAttempts = retry.MaxAttempts
+
+	durations := []struct {
+		name  string
+		value string
+		field *time.Duration
+	}{
+		{"timeout", timeout, &policy.Timeout},
+		{"initialBackoff", retry.InitialBackoff, &policy.InitialBackoff},
+		{"maxBackoff", retry.MaxBackoff, &policy.MaxBackoff},
+	}
+	for _, d := range durations {
+		if d.value == "" {
+			continue
+		}
+		duration, err := time.ParseDuration(d.value)
+		if err != nil {
+			return policy, fmt.Errorf("invalid %s: %w", d.name, err)
+		}
+		if duration <= 0 {
+			return policy, fmt.Errorf("%s must be positive, got %s", d.name, d.value)
+		}
+		*d.field = duration
+	}
+	return policy, nil
+}
+
+// defaultPolicy returns the policy of the tools without one of their own: the configured values, then the defaults
+func (g *Generator) defaultPolicy() (converter.RequestPolicy, error) {
+	policy, err := requestPolicy(g.Transport.Timeout, g.Transport.Retry)
+	if err != nil {
+		return policy, fmt.Errorf("invalid transport: %w", err)
+	}
+	return mergePolicy(converter.RequestPolicy{
+		Timeout:        DefaultTimeout,
+		MaxAttempts:    DefaultMaxAttempts,
+		InitialBackoff: DefaultInitialBackoff,
+		MaxBackoff:     DefaultMaxBackoff,
+	}, policy), nil
+}
+
+// toolPolicy returns the policy of a tool: the tool override, then x-mcp-timeout and x-mcp-retry.
+// Unset values fall back to the default policy at runtime.
+func (g *Generator) toolPolicy(tool converter.Tool) (converter.RequestPolicy, error) {
+	override, ok := g.ToolOverrides[tool.Name]
+	if !ok {
+		return tool.Policy, nil
+	}
+	policy, err := requestPolicy(override.Timeout, override.Retry)
+	if err != nil {
+		return policy, fmt.Errorf("invalid policy of tool %s: %w", tool.Name, err)
+	}
+	return mergePolicy(tool.Policy, policy), nil
+}
+
+// applyToolPolicies applies the configured tool overrides to the policies of the tools and resources
+func (g *Generator) applyToolPolicies(config *converter.MCPConfig) error {
+	for i := range config.Tools {
+		policy, err := g.toolPolicy(config.Tools[i])
+		if err != nil {
+			return err
+		}
+		config.Tools[i].Policy = policy
+	}
+	for _, resource := range config.Resources {
+		if resource.Tool == nil {
+			continue
+		}
+		policy, err := g.toolPolicy(*resource.Tool)
+		if err != nil {
+			return err
+		}
+		resource.Tool.Policy = policy
+	}
+	return nil
+}
+
+// mergePolicy returns base with the values set in overrides replacing its own
+func mergePolicy(base, overrides converter.RequestPolicy) converter.RequestPolicy {
+	if overrides.Timeout != 0 {
+		base.Timeout = overrides.Timeout
+	}
+	if overrides.MaxAttempts != 0 {
+		base.MaxAttempts = overrides.MaxAttempts
+	}
+	if overrides.InitialBackoff != 0 {
+		base.InitialBackoff = overrides.InitialBackoff
+	}
+	if overrides.MaxBackoff != 0 {
+		base.MaxBackoff = overrides.MaxBackoff
+	}
+	return base
+}
+
+// policyFields renders the values set in a policy as the fields of a Policy composite literal
+func policyFields(policy converter.RequestPolicy) string {
+	var fields []string
+	if policy.Timeout != 0 {
+		fields = append(fields, "Timeout: "+durationLiteral(policy.Timeout))
+	}
+	if policy.MaxAttempts != 0 {
+		fields = append(fields, fmt.Sprintf("MaxAttempts: %d", policy.MaxAttempts))
+	}
+	if policy.InitialBackoff != 0 {
+		fields = append(fields, "InitialBackoff: "+durationLiteral(policy.InitialBackoff))
+	}
+	if policy.MaxBackoff != 0 {
+		fields = append(fields, "MaxBackoff: "+durationLiteral(policy.MaxBackoff))
+	}
+	return strings.Join(fields, ", ")
+}
+
+// durationLiteral renders a duration as Go code in the largest unit dividing it, e.g. 90 * time.Second
+func durationLiteral(d time.Duration) string {
+	units := []struct {
+		name string
+		unit time.Duration
+	}{
+		{"time.Hour", time.Hour},
+		{"time.Minute", time.Minute},
+		{"time.Second", time.Second},
+		{"time.Millisecond", time.Millisecond},
+		{"time.Microsecond", time.Microsecond},
+	}
+	for _, u := range units {
+		if d%u.unit == 0 {
+			return fmt.Sprintf("%d * %s", d/u.unit, u.name)
+		}
+	}
+	return fmt.Sprintf("%d", d)
+}
@@ -0,0 +1,73 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lyeslabs/mcpgen/internal/converter"
+)
+
+func TestGenerator_toolPolicy(t *testing.T) {
+	g := &Generator{ToolOverrides: map[string]ToolOverride{
+		"createReport": {Timeout: "2m", Retry: RetryConfig{InitialBackoff: "1s"}},
+		"invalid":      {Retry: RetryConfig{MaxBackoff: "soon"}},
+	}}
+
+	tests := []struct {
+		name    string
+		tool    converter.Tool
+		want    converter.RequestPolicy
+		wantErr string
+	}{
+		{
+			name: "extensions only",
+			tool: converter.Tool{Name: "listReports", Policy: converter.RequestPolicy{MaxAttempts: 5}},
+			want: converter.RequestPolicy{MaxAttempts: 5},
+		},
+		{
+			name: "override replaces extensions",
+			tool: converter.Tool{Name: "createReport", Policy: converter.RequestPolicy{Timeout: time.Minute, MaxAttempts: 1}},
+			want: converter.RequestPolicy{Timeout: 2 * time.Minute, MaxAttempts: 1, InitialBackoff: time.Second},
+		},
+		{
+			name:    "invalid override",
+			tool:    converter.Tool{Name: "invalid"},
+			wantErr: "invalid policy of tool invalid: invalid maxBackoff",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := g.toolPolicy(tt.tool)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("toolPolicy() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("toolPolicy() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("toolPolicy() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_durationLiteral(t *testing.T) {
+	tests := []struct {
+		in   time.Duration
+		want string
+	}{
+		{2 * time.Hour, "2 * time.Hour"},
+		{90 * time.Second, "90 * time.Second"},
+		{1500 * time.Millisecond, "1500 * time.Millisecond"},
+		{1500, "1500"},
+	}
+	for _, tt := range tests {
+		if got := durationLiteral(tt.in); got != tt.want {
+			t.Errorf("durationLiteral(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -9,7 +9,47 @@ import (
 
 // GenerateHelpers creates a helpers.go file with utility functions for MCP tools
 func (g *Generator) GenerateHelpers() error {
-	helpersTemplate, err := templatesFS.ReadFile("templates/helpers.templ")
+	if err := g.generateHelperFile("templates/helpers.templ", "params.go"); err != nil {
+		return err
+	}
+
+	// Tool calls are checked against the input schema before reaching the handlers
+	if err := g.generateHelperFile("templates/validate.templ", "validate.go"); err != nil {
+		return err
+	}
+
+	// Binary arguments are decoded the same way by proxy handlers and implemented handlers
+	if err := g.generateHelperFile("templates/files.templ", "files.go"); err != nil {
+		return err
+	}
+
+	// Upstream requests go through the retrying, rate limited transport, also usable by implemented handlers
+	if err := g.generateHelperFile("templates/transport.templ", "transport.go"); err != nil {
+		return err
+	}
+
+	// Proxy handlers rely on the request builder, credentials and response mapping helpers
+	if g.HandlerMode == HandlerModeProxy {
+		if err := g.generateHelperFile("templates/proxy.templ", "proxy.go"); err != nil {
+			return err
+		}
+		if err := g.generateHelperFile("templates/security.templ", "security.go"); err != nil {
+			return err
+		}
+		if err := g.generateHelperFile("templates/serialize.templ", "serialize.go"); err != nil {
+			return err
+		}
+		if err := g.generateHelperFile("templates/paginate.templ", "paginate.go"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// generateHelperFile renders a helpers template into the helpers package
+func (g *Generator) generateHelperFile(templatePath, fileName string) error {
+	helpersTemplate, err := templatesFS.ReadFile(templatePath)
 	if err != nil {
 		return fmt.Errorf("failed to read helpers template file: %w", err)
 	}
@@ -19,10 +59,22 @@ func (g *Generator) GenerateHelpers() error {
 		return fmt.Errorf("failed to parse helpers template: %w", err)
 	}
 
+	policy, err := g.defaultPolicy()
+	if err != nil {
+		return err
+	}
 	data := struct {
-		PackageName string
+		PackageName       string
+		ProtocolErrors    []string
+		DefaultPolicy     string
+		RequestsPerSecond float64
+		Burst             int
 	}{
-		PackageName: g.PackageName,
+		PackageName:       g.PackageName,
+		ProtocolErrors:    g.ProtocolErrors,
+		DefaultPolicy:     policyFields(policy),
+		RequestsPerSecond: g.Transport.RateLimit.RequestsPerSecond,
+		Burst:             g.Transport.RateLimit.Burst,
 	}
 
 	var buffer bytes.Buffer
@@ -35,11 +87,11 @@ func (g *Generator) GenerateHelpers() error {
 		return fmt.Errorf("failed to format generated helpers code: %w", err)
 	}
 
-	err = writeFileContent(g.outputDir + "/helpers", "params.go", func() ([]byte, error) {
+	err = writeFileContent(g.outputDir+"/helpers", fileName, func() ([]byte, error) {
 		return formattedCode, nil
 	})
 	if err != nil {
-		return fmt.Errorf("failed to write helpers.go file: %w", err)
+		return fmt.Errorf("failed to write %s file: %w", fileName, err)
 	}
 
 	return nil
@@ -3,6 +3,7 @@ package generator
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -30,8 +31,86 @@ func TestGenerateHelpers_NoErrorOnSuccess(t *testing.T) {
 
 	// Optional: You could still check for the *existence* of the file
 	// to ensure the writeFileContent call was at least attempted.
-	expectedFilePath := filepath.Join(tmpDir, "helpers", "params.go")
-	if _, err := os.Stat(expectedFilePath); os.IsNotExist(err) {
-		t.Errorf("expected generated file %s to exist, but it does not", expectedFilePath)
+	for _, fileName := range []string{"params.go", "validate.go", "files.go", "transport.go"} {
+		expectedFilePath := filepath.Join(tmpDir, "helpers", fileName)
+		if _, err := os.Stat(expectedFilePath); os.IsNotExist(err) {
+			t.Errorf("expected generated file %s to exist, but it does not", expectedFilePath)
+		}
+	}
+}
+
+func TestGenerateHelpers_ProxyMode(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	g := &Generator{
+		PackageName: "mytools",
+		HandlerMode: HandlerModeProxy,
+		outputDir:   tmpDir,
+	}
+
+	if err := g.GenerateHelpers(); err != nil {
+		t.Fatalf("GenerateHelpers returned an unexpected error: %v", err)
+	}
+
+	for _, fileName := range []string{"params.go", "validate.go", "files.go", "transport.go", "proxy.go", "security.go", "serialize.go", "paginate.go"} {
+		expectedFilePath := filepath.Join(tmpDir, "helpers", fileName)
+		if _, err := os.Stat(expectedFilePath); os.IsNotExist(err) {
+			t.Errorf("expected generated file %s to exist, but it does not", expectedFilePath)
+		}
+	}
+}
+
+func TestGenerateHelpers_ProtocolErrors(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	g := &Generator{
+		PackageName:    "mytools",
+		HandlerMode:    HandlerModeProxy,
+		ProtocolErrors: []string{"5XX", "429"},
+		outputDir:      tmpDir,
+	}
+
+	if err := g.GenerateHelpers(); err != nil {
+		t.Fatalf("GenerateHelpers returned an unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "helpers", "proxy.go"))
+	if err != nil {
+		t.Fatalf("Failed to read proxy.go: %v", err)
+	}
+	if want := `var ProtocolErrors = []string{"5XX", "429"}`; !strings.Contains(string(data), want) {
+		t.Errorf("proxy.go missing %q", want)
+	}
+}
+
+func TestGenerateHelpers_Transport(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	g := &Generator{
+		PackageName: "mytools",
+		Transport: TransportConfig{
+			Timeout:   "1m30s",
+			Retry:     RetryConfig{MaxAttempts: 5},
+			RateLimit: RateLimitConfig{RequestsPerSecond: 2.5, Burst: 10},
+		},
+		outputDir: tmpDir,
+	}
+
+	if err := g.GenerateHelpers(); err != nil {
+		t.Fatalf("GenerateHelpers returned an unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "helpers", "transport.go"))
+	if err != nil {
+		t.Fatalf("Failed to read transport.go: %v", err)
+	}
+	for _, want := range []string{
+		// Values left unset keep their defaults
+		"var DefaultPolicy = Policy{Timeout: 90 * time.Second, MaxAttempts: 5, InitialBackoff: 500 * time.Millisecond, MaxBackoff: 30 * time.Second}",
+		"var DefaultTransport = &Transport{Limiter: NewRateLimiter(2.5, 10)}",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("transport.go missing %q", want)
+		}
 	}
 }
@@ -26,27 +26,51 @@ func (g *Generator) GenerateServerFile(config *converter.MCPConfig) error {
 		return fmt.Errorf("failed to build import path: %w", err)
 	}
 
+	helpersImportPath, err := BuildHelpersImportPath(g.outputDir)
+	if err != nil {
+		return fmt.Errorf("failed to build helpers import path: %w", err)
+	}
+
 	data := struct {
 		PackageName        string
+		ServerName         string
+		ServerVersion      string
 		MCPToolsImportPath string
+		HelpersImportPath  string
 		Tools              []ToolTemplateData
+		Resources          []resourceTemplateData
+		SchemaResources    bool
+		Prompts            bool
 	}{
 		PackageName:        g.PackageName,
+		ServerName:         valueOrDefault(g.Server.Name, DefaultServerName),
+		ServerVersion:      valueOrDefault(g.Server.Version, DefaultServerVersion),
 		Tools:              make([]ToolTemplateData, 0, len(config.Tools)),
 		MCPToolsImportPath: importPath,
+		HelpersImportPath:  helpersImportPath,
+		Prompts:            len(g.toolPrompts(config.Tools)) > 0,
 	}
 
 	for _, tool := range config.Tools {
 		capitalizedName := capitalizeFirstLetter(tool.Name)
 
 		data.Tools = append(data.Tools, ToolTemplateData{
-			ToolNameOriginal: capitalizedName,
+			ToolNameOriginal: g.toolName(tool),
 			ToolNameGo:       capitalizedName,
 			ToolHandlerName:  capitalizedName + "Handler",
-			ToolDescription:  tool.Description,
+			ToolDescription:  g.toolDescription(tool),
+			InputSchemaConst: capitalizedName + "InputSchema",
 		})
 	}
 
+	for _, resource := range config.Resources {
+		if resource.Tool == nil {
+			data.SchemaResources = true
+			continue
+		}
+		data.Resources = append(data.Resources, g.newResourceTemplateData(resource, config.Server.SecuritySchemes))
+	}
+
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, data); err != nil {
 		return fmt.Errorf("failed to render server template: %w", err)
@@ -24,24 +24,41 @@ func Test_RenderAndWriteServerTemplate(t *testing.T) {
 			ToolNameGo:       "Echo",
 			ToolHandlerName:  "EchoHandler",
 			ToolDescription:  "Echoes input",
+			InputSchemaConst: "EchoInputSchema",
 		},
 		{
 			ToolNameOriginal: "Reverse",
 			ToolNameGo:       "Reverse",
 			ToolHandlerName:  "ReverseHandler",
 			ToolDescription:  "Reverses input",
+			InputSchemaConst: "ReverseInputSchema",
 		},
 	}
 
 	// Prepare the data struct as GenerateServerFile would
 	data := struct {
 		PackageName        string
+		ServerName         string
+		ServerVersion      string
 		MCPToolsImportPath string
+		HelpersImportPath  string
 		Tools              []ToolTemplateData
+		Resources          []resourceTemplateData
+		SchemaResources    bool
+		Prompts            bool
 	}{
 		PackageName:        "mytools",
+		ServerName:         "Todo Server",
+		ServerVersion:      "2.1.0",
 		MCPToolsImportPath: "github.com/example/project/mcptools",
+		HelpersImportPath:  "github.com/example/project/helpers",
 		Tools:              tools,
+		Resources: []resourceTemplateData{
+			{GoName: "GetTodoResource", HandlerName: "GetTodoResourceHandler", Template: true},
+			{GoName: "ListTodosResource", HandlerName: "ListTodosResourceHandler"},
+		},
+		SchemaResources: true,
+		Prompts:         true,
 	}
 
 	// Parse and render the template
@@ -82,4 +99,19 @@ func Test_RenderAndWriteServerTemplate(t *testing.T) {
 	if !strings.Contains(strContent, "EchoHandler") || !strings.Contains(strContent, "ReverseHandler") {
 		t.Errorf("Generated file missing expected handler names")
 	}
+	if !strings.Contains(strContent, `"Todo Server",`) || !strings.Contains(strContent, `"2.1.0",`) {
+		t.Errorf("Generated file missing server name and version")
+	}
+	for _, want := range []string{
+		`mcputils "github.com/example/project/helpers"`,
+		"s.AddTool(mcptools.NewEchoMCPTool(), mcputils.ValidateArguments(mcptools.EchoInputSchema, mcptools.EchoHandler))",
+		"s.AddResourceTemplate(mcptools.NewGetTodoResourceMCPResourceTemplate(), mcptools.GetTodoResourceHandler)",
+		"s.AddResource(mcptools.NewListTodosResourceMCPResource(), mcptools.ListTodosResourceHandler)",
+		"s.AddResources(mcptools.SchemaResources()...)",
+		"s.AddPrompts(mcptools.Prompts()...)",
+	} {
+		if !strings.Contains(strContent, want) {
+			t.Errorf("Generated file missing %q", want)
+		}
+	}
 }